@@ -2,6 +2,8 @@ package ring_test
 
 import (
 	"fmt"
+	"runtime"
+	"sync"
 	"testing"
 
 	"github.com/stretchr/testify/require"
@@ -67,3 +69,53 @@ func BenchmarkMuQ_push_burst(b *testing.B) {
 		})
 	}
 }
+
+func BenchmarkSPSC_pushPop_sameRate(b *testing.B) {
+	q := ring.NewSPSC[int](16)
+	for i := 0; i < b.N; i++ {
+		q.TryPush(i)
+		q.TryPop()
+	}
+}
+
+// The pipeline benchmarks connect one producer goroutine
+// to one consumer goroutine through a queue.
+// This is the use case SPSC is designed for.
+
+func BenchmarkSPSC_pipeline(b *testing.B) {
+	q := ring.NewSPSC[int](1024)
+	benchmarkPipeline(b, q.TryPush, q.TryPop)
+}
+
+func BenchmarkMuQ_pipeline(b *testing.B) {
+	q := ring.NewMuQWithOptions[int](1024, ring.WithMaxCapacity(1024))
+	benchmarkPipeline(b, q.TryPush, q.TryPop)
+}
+
+func benchmarkPipeline(b *testing.B, push func(int) bool, pop func() (int, bool)) {
+	var wg sync.WaitGroup
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		for i := 0; i < b.N; {
+			if push(i) {
+				i++
+			} else {
+				runtime.Gosched()
+			}
+		}
+	}()
+
+	for i := 0; i < b.N; {
+		x, ok := pop()
+		if !ok {
+			runtime.Gosched()
+			continue
+		}
+		if x != i {
+			b.Fatalf("got %d, want %d", x, i)
+		}
+		i++
+	}
+	wg.Wait()
+}
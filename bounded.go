@@ -0,0 +1,182 @@
+package ring
+
+import (
+	"fmt"
+	"iter"
+)
+
+// BoundedQ is a FIFO queue with a fixed capacity backed by a ring buffer.
+// When the queue is full, pushing a new item evicts the oldest item.
+//
+// This makes it suitable to hold the last N items of a stream,
+// e.g. the most recent requests handled by a server.
+//
+// The zero value for BoundedQ is a queue with capacity zero:
+// every item pushed into it is evicted immediately.
+// Use [NewBoundedQ] to create a queue with a non-zero capacity.
+//
+// BoundedQ is not safe for concurrent use.
+type BoundedQ[T any] struct {
+	q Q[T]
+
+	// capacity is the maximum number of items in the queue.
+	capacity int
+
+	// overwritten is the number of items evicted by Push.
+	overwritten int
+}
+
+// NewBoundedQ returns a new queue that holds at most capacity items.
+// It panics if capacity is negative.
+//
+// The memory for the queue is allocated upfront.
+// The queue never grows or shrinks.
+func NewBoundedQ[T any](capacity int) *BoundedQ[T] {
+	if capacity < 0 {
+		panic(fmt.Sprintf("invalid capacity: %d", capacity))
+	}
+
+	b := BoundedQ[T]{capacity: capacity}
+	if capacity > 0 {
+		b.q.init(capacity)
+	}
+	return &b
+}
+
+// Empty returns true if the queue is empty.
+//
+// This is an O(1) operation and does not allocate.
+func (b *BoundedQ[T]) Empty() bool {
+	return b.q.Empty()
+}
+
+// Full returns true if the queue is at capacity.
+// The next Push will evict the oldest item.
+//
+// This is an O(1) operation and does not allocate.
+func (b *BoundedQ[T]) Full() bool {
+	return b.q.Len() == b.capacity
+}
+
+// Len returns the number of items in the queue.
+//
+// This is an O(1) operation and does not allocate.
+func (b *BoundedQ[T]) Len() int {
+	return b.q.Len()
+}
+
+// Cap returns the maximum number of items the queue can hold.
+//
+// This is an O(1) operation and does not allocate.
+func (b *BoundedQ[T]) Cap() int {
+	return b.capacity
+}
+
+// Overwritten returns the number of items that were evicted
+// by Push because the queue was full.
+//
+// Items removed with Pop or Clear are not counted.
+func (b *BoundedQ[T]) Overwritten() int {
+	return b.overwritten
+}
+
+// Clear removes all items from the queue.
+// It does not reset the count reported by Overwritten.
+//
+// This is an O(n) operation as it zeroes out the removed items
+// so that they may be garbage collected.
+// It does not allocate.
+func (b *BoundedQ[T]) Clear() {
+	b.q.Clear()
+}
+
+// Push adds x to the back of the queue.
+//
+// If the queue is full, the item at the front of the queue is evicted
+// to make room for x.
+// In that case, Push returns the evicted item and true.
+// Otherwise, it returns false.
+//
+// This is an O(1) operation and does not allocate.
+func (b *BoundedQ[T]) Push(x T) (evicted T, ok bool) {
+	if b.capacity == 0 {
+		b.overwritten++
+		return x, true
+	}
+
+	if b.q.Len() == b.capacity {
+		evicted, ok = b.q.TryPop()
+		b.overwritten++
+	}
+
+	// The buffer has room for capacity items,
+	// so this will never grow.
+	b.q.Push(x)
+	return evicted, ok
+}
+
+// Pop removes and returns the item at the front of the queue.
+// This is the oldest item in the queue.
+// It panics if the queue is empty.
+//
+// This is an O(1) operation and does not allocate.
+func (b *BoundedQ[T]) Pop() T {
+	x, ok := b.TryPop()
+	if !ok {
+		panic("empty queue")
+	}
+	return x
+}
+
+// TryPop removes and returns the item at the front of the queue.
+// This is the oldest item in the queue.
+// It returns false if the queue is empty.
+// Otherwise, it returns true and the item.
+//
+// This is an O(1) operation and does not allocate.
+func (b *BoundedQ[T]) TryPop() (x T, ok bool) {
+	return b.q.TryPop()
+}
+
+// Peek returns the item at the front of the queue without removing it.
+// This is the oldest item in the queue.
+// It panics if the queue is empty.
+//
+// This is an O(1) operation and does not allocate.
+func (b *BoundedQ[T]) Peek() T {
+	x, ok := b.TryPeek()
+	if !ok {
+		panic("empty queue")
+	}
+	return x
+}
+
+// TryPeek returns the item at the front of the queue.
+// This is the oldest item in the queue.
+// It returns false if the queue is empty.
+// Otherwise, it returns true and the item.
+//
+// This is an O(1) operation and does not allocate.
+func (b *BoundedQ[T]) TryPeek() (x T, ok bool) {
+	return b.q.TryPeek()
+}
+
+// Snapshot appends the contents of the queue to dst and returns the result.
+// Items are appended oldest to newest.
+//
+// Use dst to avoid allocations when you know the capacity of the queue
+// or pass nil to let the function allocate a new slice.
+//
+// The returned slice is a copy of the internal buffer and is safe to modify.
+func (b *BoundedQ[T]) Snapshot(dst []T) []T {
+	return b.q.Snapshot(dst)
+}
+
+// All returns an iterator over the items in the queue
+// and their positions, oldest to newest.
+// The queue is not modified.
+//
+// As with [Q.All], the queue must not be modified while iterating over it.
+func (b *BoundedQ[T]) All() iter.Seq2[int, T] {
+	return b.q.All()
+}
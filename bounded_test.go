@@ -0,0 +1,133 @@
+package ring_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"go.abhg.dev/container/ring"
+	"pgregory.net/rapid"
+)
+
+func TestBoundedQ_zeroValue(t *testing.T) {
+	t.Parallel()
+
+	var q ring.BoundedQ[int]
+	assert.True(t, q.Empty(), "empty")
+	assert.True(t, q.Full(), "full")
+	assert.Zero(t, q.Cap(), "cap")
+
+	evicted, ok := q.Push(42)
+	assert.True(t, ok, "push must evict")
+	assert.Equal(t, 42, evicted, "evicted")
+	assert.True(t, q.Empty(), "empty")
+	assert.Equal(t, 1, q.Overwritten(), "overwritten")
+	assert.Panics(t, func() { q.Pop() }, "pop")
+	assert.Panics(t, func() { q.Peek() }, "peek")
+}
+
+func TestBoundedQ_negativeCapacity(t *testing.T) {
+	t.Parallel()
+
+	assert.PanicsWithValue(t, "invalid capacity: -1", func() {
+		ring.NewBoundedQ[int](-1)
+	})
+}
+
+func TestBoundedQ_overwrite(t *testing.T) {
+	t.Parallel()
+
+	q := ring.NewBoundedQ[int](3)
+	for i := 0; i < 3; i++ {
+		_, ok := q.Push(i)
+		assert.False(t, ok, "push %d must not evict", i)
+	}
+	assert.True(t, q.Full(), "full")
+
+	for i := 3; i < 10; i++ {
+		evicted, ok := q.Push(i)
+		assert.True(t, ok, "push %d must evict", i)
+		assert.Equal(t, i-3, evicted, "evicted")
+	}
+
+	assert.Equal(t, []int{7, 8, 9}, q.Snapshot(nil), "snapshot")
+	assert.Equal(t, 7, q.Overwritten(), "overwritten")
+
+	q.Clear()
+	assert.True(t, q.Empty(), "empty after clear")
+	assert.Equal(t, 7, q.Overwritten(), "overwritten after clear")
+}
+
+func TestBoundedQ_rapid(t *testing.T) {
+	t.Parallel()
+
+	rapid.Check(t, func(t *rapid.T) {
+		capacity := rapid.IntRange(0, 20).Draw(t, "capacity")
+		t.Repeat(rapid.StateMachineActions(&boundedQMachine{
+			q:        ring.NewBoundedQ[int](capacity),
+			capacity: capacity,
+		}))
+	})
+}
+
+type boundedQMachine struct {
+	q        *ring.BoundedQ[int]
+	capacity int
+
+	golden      []int
+	overwritten int
+}
+
+var _ rapid.StateMachine = (*boundedQMachine)(nil)
+
+func (m *boundedQMachine) Check(t *rapid.T) {
+	assert.Equal(t, len(m.golden), m.q.Len(), "len")
+	assert.Equal(t, len(m.golden) == m.capacity, m.q.Full(), "full")
+	assert.Equal(t, m.overwritten, m.q.Overwritten(), "overwritten")
+	got := m.q.Snapshot(nil)
+	for i, want := range m.golden {
+		assert.Equal(t, want, got[i], "item %d", i)
+	}
+}
+
+func (m *boundedQMachine) Push(t *rapid.T) {
+	x := rapid.Int().Draw(t, "x")
+	evicted, ok := m.q.Push(x)
+
+	m.golden = append(m.golden, x)
+	if len(m.golden) > m.capacity {
+		assert.True(t, ok, "must evict")
+		assert.Equal(t, m.golden[0], evicted, "evicted")
+		m.golden = m.golden[1:]
+		m.overwritten++
+	} else {
+		assert.False(t, ok, "must not evict")
+	}
+}
+
+func (m *boundedQMachine) TryPop(t *rapid.T) {
+	got, ok := m.q.TryPop()
+	if len(m.golden) == 0 {
+		assert.False(t, ok)
+		return
+	}
+
+	assert.True(t, ok)
+	assert.Equal(t, m.golden[0], got)
+	m.golden = m.golden[1:]
+}
+
+func (m *boundedQMachine) TryPeek(t *rapid.T) {
+	got, ok := m.q.TryPeek()
+	if len(m.golden) == 0 {
+		assert.False(t, ok)
+		return
+	}
+
+	assert.True(t, ok)
+	assert.Equal(t, m.golden[0], got)
+}
+
+func (m *boundedQMachine) Clear(_ *rapid.T) {
+	m.q.Clear()
+	m.golden = nil
+}
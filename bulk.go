@@ -0,0 +1,144 @@
+package ring
+
+import "fmt"
+
+// PushSlice adds the items in xs to the back of the queue, in order.
+// If the queue was created with [WithMaxCapacity]
+// and it doesn't have room for all items,
+// PushSlice panics with [ErrFull] without adding any of them.
+//
+// The queue grows at most once to make room for all items,
+// and the items are copied into it in bulk.
+// This is more efficient than calling Push for each item.
+func (q *Q[T]) PushSlice(xs ...T) {
+	if !q.TryPushSlice(xs...) {
+		panic(ErrFull)
+	}
+}
+
+// TryPushSlice adds the items in xs to the back of the queue, in order.
+// It returns false without adding any items
+// if the queue was created with [WithMaxCapacity]
+// and it doesn't have room for all of them.
+// Otherwise, it returns true.
+//
+// See [Q.PushSlice] for details.
+func (q *Q[T]) TryPushSlice(xs ...T) bool {
+	if len(xs) == 0 {
+		return true
+	}
+	if q.maxCap > 0 && q.Len()+len(xs) > q.maxCap {
+		return false
+	}
+
+	q.mutate()
+	q.reserve(len(xs))
+
+	// reserve guarantees that there are at least len(xs) free slots
+	// starting at tail, possibly wrapping around to the start of buff.
+	n := copy(q.buff[q.tail:], xs)
+	copy(q.buff, xs[n:])
+
+	q.tail += len(xs)
+	if q.tail >= len(q.buff) {
+		q.tail -= len(q.buff)
+	}
+	return true
+}
+
+// PopN removes up to n items from the front of the queue,
+// appends them to dst in order, and returns the result.
+// If the queue has fewer than n items, all of them are removed.
+// It panics if n is negative.
+//
+// Use dst to avoid allocations:
+//
+//	batch = q.PopN(batch[:0], 100)
+func (q *Q[T]) PopN(dst []T, n int) []T {
+	dst = q.PeekN(dst, n)
+	q.DiscardN(n)
+	return dst
+}
+
+// PeekN appends up to n items from the front of the queue to dst
+// without removing them, and returns the result.
+// If the queue has fewer than n items, all of them are appended.
+// It panics if n is negative.
+//
+// The returned slice is a copy of the internal buffer and is safe to modify.
+func (q *Q[T]) PeekN(dst []T, n int) []T {
+	checkCount(n)
+	a, b := q.span(0, min(n, q.Len()))
+	dst = append(dst, a...)
+	return append(dst, b...)
+}
+
+// DiscardN removes up to n items from the front of the queue
+// without returning them.
+// It returns the number of items removed,
+// which is less than n only if the queue has fewer than n items.
+// It panics if n is negative.
+//
+// The removed items are zeroed out so that they may be garbage collected.
+func (q *Q[T]) DiscardN(n int) int {
+	checkCount(n)
+	n = min(n, q.Len())
+	if n == 0 {
+		return 0
+	}
+
+	q.mutate()
+	a, b := q.span(0, n)
+	clear(a)
+	clear(b)
+
+	q.head += n
+	if q.head >= len(q.buff) {
+		q.head -= len(q.buff)
+	}
+	q.maybeShrink()
+	return n
+}
+
+// reserve grows the queue if needed
+// so that n more items may be added without allocating.
+func (q *Q[T]) reserve(n int) {
+	want := q.Len() + n
+	if want <= q.Cap() {
+		return
+	}
+
+	// Grow in the same increments as Push would
+	// until there's enough room, and then resize once.
+	size := len(q.buff)
+	for size-1 < want {
+		size = q.grownSize(size)
+	}
+	q.resize(size)
+}
+
+// span returns the portions of buff
+// that hold the items at positions [i, j) in the queue.
+// The second slice is non-empty only if the range wraps around
+// the end of the buffer.
+//
+// i and j must be in the range [0, Len()] with i <= j.
+func (q *Q[T]) span(i, j int) (a, b []T) {
+	if i == j {
+		return nil, nil
+	}
+
+	start := q.index(i)
+	end := start + (j - i)
+	if end <= len(q.buff) {
+		return q.buff[start:end], nil
+	}
+	return q.buff[start:], q.buff[:end-len(q.buff)]
+}
+
+// checkCount panics if n is not a valid number of items.
+func checkCount(n int) {
+	if n < 0 {
+		panic(fmt.Sprintf("invalid count: %d", n))
+	}
+}
@@ -0,0 +1,106 @@
+package ring
+
+import (
+	"fmt"
+	"slices"
+)
+
+// Clone returns a copy of the queue.
+// The copy has its own buffer with the same capacity as the original,
+// and the same options (e.g. [WithMaxCapacity]) as the original.
+//
+// The items themselves are copied by assignment,
+// so this is a shallow clone.
+//
+// Use Clone instead of copying a Q by value:
+// copies of a Q share the same buffer and corrupt each other.
+func (q *Q[T]) Clone() *Q[T] {
+	c := *q
+	c.buff = slices.Clone(q.buff)
+	c.viewed = false
+	return &c
+}
+
+// Equal reports whether two queues hold the same items in the same order.
+// Items are compared with ==.
+// The capacity of the queues and the layout of their buffers don't matter.
+func Equal[T comparable](q1, q2 *Q[T]) bool {
+	return EqualFunc(q1, q2, func(a, b T) bool { return a == b })
+}
+
+// EqualFunc reports whether two queues hold the same items in the same order,
+// using eq to compare items.
+// It behaves like [slices.EqualFunc].
+func EqualFunc[T1, T2 any](q1 *Q[T1], q2 *Q[T2], eq func(T1, T2) bool) bool {
+	n := q1.Len()
+	if n != q2.Len() {
+		return false
+	}
+
+	for i := 0; i < n; i++ {
+		if !eq(q1.buff[q1.index(i)], q2.buff[q2.index(i)]) {
+			return false
+		}
+	}
+	return true
+}
+
+// AppendQueue moves all items from other to the back of q,
+// keeping their order, and leaves other empty.
+// If other is q, AppendQueue does nothing.
+//
+// If q was created with [WithMaxCapacity]
+// and it doesn't have room for all items in other,
+// AppendQueue panics with [ErrFull] without moving any of them.
+//
+// q grows at most once to make room,
+// and items are copied from other in bulk.
+func (q *Q[T]) AppendQueue(other *Q[T]) {
+	if q == other {
+		return
+	}
+
+	n := other.Len()
+	if q.maxCap > 0 && q.Len()+n > q.maxCap {
+		panic(ErrFull)
+	}
+
+	q.reserve(n)
+	a, b := other.span(0, n)
+	q.TryPushSlice(a...)
+	q.TryPushSlice(b...)
+	other.Clear()
+}
+
+// Split splits the queue in two at position i.
+// The items at positions [0, i) remain in q,
+// and the items at positions [i, Len()) are moved to a new queue,
+// which is returned.
+// It panics if i is out of range [0, Len()].
+//
+// The new queue has just enough capacity to hold the moved items,
+// and the same options (e.g. [WithMaxCapacity]) as q.
+// If no items are moved, the new queue has no capacity
+// and allocates on its first push, like the zero value.
+func (q *Q[T]) Split(i int) *Q[T] {
+	n := q.Len()
+	if i < 0 || i > n {
+		panic(fmt.Sprintf("index out of range [%d] with length %d", i, n))
+	}
+
+	r := Q[T]{
+		maxCap: q.maxCap,
+		shrink: q.shrink,
+		growth: q.growth,
+	}
+	if i == n {
+		return &r
+	}
+	r.init(n - i)
+
+	a, b := q.span(i, n)
+	r.TryPushSlice(a...)
+	r.TryPushSlice(b...)
+	q.RemoveRange(i, n)
+	return &r
+}
@@ -0,0 +1,264 @@
+package ring_test
+
+import (
+	"strconv"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.abhg.dev/container/ring"
+	"pgregory.net/rapid"
+)
+
+func TestQ_Clone(t *testing.T) {
+	t.Parallel()
+
+	t.Run("Zero", func(t *testing.T) {
+		t.Parallel()
+
+		var q ring.Q[int]
+		c := q.Clone()
+		assert.True(t, c.Empty())
+
+		c.Push(1)
+		assert.True(t, q.Empty())
+	})
+
+	t.Run("Wrapped", func(t *testing.T) {
+		t.Parallel()
+
+		q := newWrappedQ()
+		c := q.Clone()
+		assert.Equal(t, q.Cap(), c.Cap())
+		assert.Equal(t, []int{1, 2, 3, 4, 5}, c.Snapshot(nil))
+
+		// The two queues don't share a buffer.
+		c.Set(0, 10)
+		c.Push(6)
+		assert.Equal(t, 1, q.Peek())
+		assert.Equal(t, []int{1, 2, 3, 4, 5}, q.Snapshot(nil))
+		assert.Equal(t, []int{10, 2, 3, 4, 5, 6}, c.Snapshot(nil))
+	})
+
+	t.Run("KeepsOptions", func(t *testing.T) {
+		t.Parallel()
+
+		q := ring.NewQWithOptions[int](2, ring.WithMaxCapacity(2))
+		q.Push(1)
+		c := q.Clone()
+		assert.True(t, c.TryPush(2))
+		assert.False(t, c.TryPush(3))
+	})
+}
+
+func TestEqual(t *testing.T) {
+	t.Parallel()
+
+	var empty ring.Q[int]
+	assert.True(t, ring.Equal(&empty, ring.NewQ[int](4)))
+
+	// Same contents, different layouts and capacities.
+	q := ring.NewQ[int](16)
+	q.PushSlice(1, 2, 3, 4, 5)
+	assert.True(t, ring.Equal(q, newWrappedQ()))
+
+	q.Set(4, 6)
+	assert.False(t, ring.Equal(q, newWrappedQ()))
+
+	q.Pop()
+	assert.False(t, ring.Equal(q, newWrappedQ()))
+}
+
+func TestEqualFunc(t *testing.T) {
+	t.Parallel()
+
+	strs := ring.NewQ[string](0)
+	strs.PushSlice("1", "2", "3", "4", "5")
+
+	eq := func(i int, s string) bool {
+		return strconv.Itoa(i) == s
+	}
+	assert.True(t, ring.EqualFunc(newWrappedQ(), strs, eq))
+
+	strs.Push("6")
+	assert.False(t, ring.EqualFunc(newWrappedQ(), strs, eq))
+}
+
+func TestQ_AppendQueue(t *testing.T) {
+	t.Parallel()
+
+	t.Run("Wrapped", func(t *testing.T) {
+		t.Parallel()
+
+		q, other := newWrappedQ(), newWrappedQ()
+		q.AppendQueue(other)
+		assert.Equal(t, []int{1, 2, 3, 4, 5, 1, 2, 3, 4, 5}, q.Snapshot(nil))
+		assert.True(t, other.Empty())
+
+		// other is still usable.
+		other.Push(42)
+		assert.Equal(t, []int{42}, other.Snapshot(nil))
+	})
+
+	t.Run("Self", func(t *testing.T) {
+		t.Parallel()
+
+		q := newWrappedQ()
+		q.AppendQueue(q)
+		assert.Equal(t, []int{1, 2, 3, 4, 5}, q.Snapshot(nil))
+	})
+
+	t.Run("Full", func(t *testing.T) {
+		t.Parallel()
+
+		q := ring.NewQWithOptions[int](4, ring.WithMaxCapacity(4))
+		q.PushSlice(1, 2)
+		other := newWrappedQ()
+
+		assert.PanicsWithValue(t, ring.ErrFull, func() {
+			q.AppendQueue(other)
+		})
+		assert.Equal(t, []int{1, 2}, q.Snapshot(nil))
+		assert.Equal(t, []int{1, 2, 3, 4, 5}, other.Snapshot(nil))
+	})
+}
+
+func TestQ_Split(t *testing.T) {
+	t.Parallel()
+
+	for i := 0; i <= 5; i++ {
+		t.Run(strconv.Itoa(i), func(t *testing.T) {
+			t.Parallel()
+
+			q := newWrappedQ()
+			r := q.Split(i)
+			assert.Equal(t, []int{1, 2, 3, 4, 5}[:i], q.Snapshot([]int{}))
+			assert.Equal(t, []int{1, 2, 3, 4, 5}[i:], r.Snapshot([]int{}))
+		})
+	}
+
+	t.Run("Capacity", func(t *testing.T) {
+		t.Parallel()
+
+		q := newWrappedQ()
+		r := q.Split(3)
+		assert.Equal(t, 2, r.Cap())
+
+		// Nothing moved: no capacity.
+		r = q.Split(q.Len())
+		assert.Zero(t, r.Cap())
+		assert.True(t, r.Empty())
+
+		r.Push(1)
+		assert.Equal(t, []int{1}, r.Snapshot(nil))
+	})
+
+	t.Run("EmptyKeepsOptions", func(t *testing.T) {
+		t.Parallel()
+
+		q := ring.NewQWithOptions[int](2, ring.WithMaxCapacity(2))
+		r := q.Split(0)
+		assert.True(t, r.TryPush(1))
+		assert.True(t, r.TryPush(2))
+		assert.False(t, r.TryPush(3))
+	})
+
+	t.Run("OutOfRange", func(t *testing.T) {
+		t.Parallel()
+
+		q := newWrappedQ()
+		assert.PanicsWithValue(t, "index out of range [6] with length 5", func() {
+			q.Split(6)
+		})
+		assert.PanicsWithValue(t, "index out of range [-1] with length 5", func() {
+			q.Split(-1)
+		})
+	})
+}
+
+func TestQ_SplitAppendQueue_rapid(t *testing.T) {
+	t.Parallel()
+
+	rapid.Check(t, func(t *rapid.T) {
+		items := rapid.SliceOf(rapid.Int()).Draw(t, "items")
+		offset := rapid.IntRange(0, 32).Draw(t, "offset")
+
+		// Rotate the ring so the items start at an arbitrary offset.
+		q := ring.NewQ[int](len(items))
+		for range offset {
+			q.Push(0)
+			q.Pop()
+		}
+		q.PushSlice(items...)
+
+		i := rapid.IntRange(0, len(items)).Draw(t, "i")
+		r := q.Split(i)
+		require.Equal(t, i, q.Len())
+		require.Equal(t, len(items)-i, r.Len())
+
+		q.AppendQueue(r)
+		require.True(t, r.Empty())
+
+		want := ring.NewQ[int](0)
+		want.PushSlice(items...)
+		require.True(t, ring.Equal(want, q))
+	})
+}
+
+func TestMuQ_Clone(t *testing.T) {
+	t.Parallel()
+
+	var q ring.MuQ[int]
+	q.PushSlice(1, 2, 3)
+
+	c := q.Clone()
+	c.Push(4)
+	assert.Equal(t, []int{1, 2, 3}, q.Snapshot(nil))
+	assert.Equal(t, []int{1, 2, 3, 4}, c.Snapshot(nil))
+}
+
+func TestMuQ_AppendQueue(t *testing.T) {
+	t.Parallel()
+
+	var q, other ring.MuQ[int]
+	q.PushSlice(1, 2)
+	other.PushSlice(3, 4)
+
+	q.AppendQueue(&q)
+	assert.Equal(t, []int{1, 2}, q.Snapshot(nil))
+
+	q.AppendQueue(&other)
+	assert.Equal(t, []int{1, 2, 3, 4}, q.Snapshot(nil))
+	assert.True(t, other.Empty())
+}
+
+// Appending two queues into each other concurrently must not deadlock,
+// and no items may be lost.
+func TestMuQ_AppendQueue_lockOrder(t *testing.T) {
+	t.Parallel()
+
+	const Steps = 1000
+
+	var a, b ring.MuQ[int]
+	a.PushSlice(1, 2, 3)
+	b.PushSlice(4, 5)
+
+	var wg sync.WaitGroup
+	wg.Add(2)
+	go func() {
+		defer wg.Done()
+		for range Steps {
+			a.AppendQueue(&b)
+		}
+	}()
+	go func() {
+		defer wg.Done()
+		for range Steps {
+			b.AppendQueue(&a)
+		}
+	}()
+	wg.Wait()
+
+	assert.Equal(t, 5, a.Len()+b.Len())
+}
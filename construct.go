@@ -0,0 +1,109 @@
+package ring
+
+import (
+	"iter"
+	"slices"
+)
+
+// FromSlice returns a new queue holding the items in xs,
+// with the first item of xs at the front of the queue.
+// The queue has just enough capacity to hold the items,
+// or the default capacity if xs is empty.
+//
+// The items are copied into the queue;
+// xs is not retained.
+func FromSlice[T any](xs []T) *Q[T] {
+	var q Q[T]
+	q.fromSlice(xs)
+	return &q
+}
+
+// Collect returns a new queue holding the items yielded by seq,
+// with the first item yielded at the front of the queue.
+//
+// The queue grows as needed while items are added,
+// the same as if they were pushed one at a time.
+// If you know the number of items in advance,
+// use [NewQ] and [Q.Push] instead to avoid growing the queue.
+func Collect[T any](seq iter.Seq[T]) *Q[T] {
+	var q Q[T]
+	q.collect(seq)
+	return &q
+}
+
+// Adopt returns a new queue holding the items in buf,
+// with the first item of buf at the front of the queue.
+//
+// The queue takes ownership of buf and uses it as its buffer
+// until it needs to grow.
+// The caller must not use buf after calling Adopt.
+// This makes it possible to supply memory from a pool or an arena.
+//
+// The queue needs one spare slot beyond the items it holds,
+// so its capacity is cap(buf) - 1.
+// To avoid allocating, buf must have room for at least one more item:
+// len(buf) < cap(buf).
+// Otherwise, Adopt grows buf like append would.
+// For example:
+//
+//	buf := make([]int, 0, 129)
+//	buf = append(buf, items...) // len(items) == 128
+//	q := ring.Adopt(buf)        // q.Len() == q.Cap() == 128
+//
+// The slots of buf beyond len(buf) are zeroed out.
+func Adopt[T any](buf []T) *Q[T] {
+	var q Q[T]
+	q.adopt(buf)
+	return &q
+}
+
+// MuQFromSlice returns a new thread-safe queue holding the items in xs.
+//
+// See [FromSlice] for details.
+func MuQFromSlice[T any](xs []T) *MuQ[T] {
+	var m MuQ[T]
+	m.q.fromSlice(xs)
+	return &m
+}
+
+// MuQCollect returns a new thread-safe queue holding the items yielded by seq.
+//
+// See [Collect] for details.
+func MuQCollect[T any](seq iter.Seq[T]) *MuQ[T] {
+	var m MuQ[T]
+	m.q.collect(seq)
+	return &m
+}
+
+// MuQAdopt returns a new thread-safe queue holding the items in buf,
+// taking ownership of buf as its buffer.
+//
+// See [Adopt] for details.
+func MuQAdopt[T any](buf []T) *MuQ[T] {
+	var m MuQ[T]
+	m.q.adopt(buf)
+	return &m
+}
+
+func (q *Q[T]) fromSlice(xs []T) {
+	q.init(len(xs))
+	q.PushSlice(xs...)
+}
+
+func (q *Q[T]) collect(seq iter.Seq[T]) {
+	for x := range seq {
+		q.Push(x)
+	}
+}
+
+func (q *Q[T]) adopt(buf []T) {
+	n := len(buf)
+	buf = slices.Grow(buf, 1)
+	buf = buf[:cap(buf)]
+	clear(buf[n:])
+
+	q.buff = buf
+	q.head = 0
+	q.tail = n // buf has at least one spare slot, so tail < len(buf)
+	q.minCap = len(buf) - 1
+}
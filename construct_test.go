@@ -0,0 +1,155 @@
+package ring_test
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"go.abhg.dev/container/ring"
+)
+
+func TestFromSlice(t *testing.T) {
+	t.Parallel()
+
+	t.Run("Empty", func(t *testing.T) {
+		t.Parallel()
+
+		q := ring.FromSlice[int](nil)
+		assert.True(t, q.Empty())
+		assert.Equal(t, 16, q.Cap())
+	})
+
+	t.Run("Items", func(t *testing.T) {
+		t.Parallel()
+
+		xs := []int{1, 2, 3}
+		q := ring.FromSlice(xs)
+		assert.Equal(t, 3, q.Cap())
+		assert.Equal(t, []int{1, 2, 3}, q.Snapshot(nil))
+
+		// xs is not retained.
+		xs[0] = 10
+		assert.Equal(t, 1, q.Peek())
+	})
+
+	t.Run("MuQ", func(t *testing.T) {
+		t.Parallel()
+
+		q := ring.MuQFromSlice([]int{1, 2, 3})
+		assert.Equal(t, []int{1, 2, 3}, q.Snapshot(nil))
+	})
+}
+
+func TestCollect(t *testing.T) {
+	t.Parallel()
+
+	t.Run("Empty", func(t *testing.T) {
+		t.Parallel()
+
+		q := ring.Collect(slices.Values([]int{}))
+		assert.True(t, q.Empty())
+
+		q.Push(1)
+		assert.Equal(t, 1, q.Pop())
+	})
+
+	t.Run("Items", func(t *testing.T) {
+		t.Parallel()
+
+		q := ring.Collect(slices.Values([]int{1, 2, 3, 4, 5}))
+		assert.Equal(t, []int{1, 2, 3, 4, 5}, q.Snapshot(nil))
+	})
+
+	t.Run("Drain", func(t *testing.T) {
+		t.Parallel()
+
+		src := newWrappedQ()
+		q := ring.Collect(src.Drain())
+		assert.Equal(t, []int{1, 2, 3, 4, 5}, q.Snapshot(nil))
+		assert.True(t, src.Empty())
+	})
+
+	t.Run("MuQ", func(t *testing.T) {
+		t.Parallel()
+
+		q := ring.MuQCollect(slices.Values([]int{1, 2, 3}))
+		assert.Equal(t, []int{1, 2, 3}, q.Snapshot(nil))
+	})
+}
+
+func TestAdopt(t *testing.T) {
+	t.Parallel()
+
+	t.Run("Nil", func(t *testing.T) {
+		t.Parallel()
+
+		q := ring.Adopt[int](nil)
+		assert.True(t, q.Empty())
+
+		q.PushSlice(1, 2, 3)
+		assert.Equal(t, []int{1, 2, 3}, q.Snapshot(nil))
+	})
+
+	t.Run("Full", func(t *testing.T) {
+		t.Parallel()
+
+		buf := make([]int, 0, 4)
+		buf = append(buf, 1, 2, 3)
+		q := ring.Adopt(buf)
+		assert.Equal(t, 3, q.Len())
+		assert.Equal(t, 3, q.Cap())
+		assert.Equal(t, []int{1, 2, 3}, q.Snapshot(nil))
+
+		// The queue uses buf as its buffer.
+		q.Set(0, 10)
+		assert.Equal(t, 10, buf[0])
+
+		// Until it grows.
+		q.Push(4)
+		q.Set(0, 20)
+		assert.Equal(t, 10, buf[0])
+		assert.Equal(t, []int{20, 2, 3, 4}, q.Snapshot(nil))
+	})
+
+	t.Run("SpareCapacity", func(t *testing.T) {
+		t.Parallel()
+
+		// Leftover items in the spare capacity are zeroed.
+		buf := []int{1, 2, 3, 4, 5}[:2]
+		q := ring.Adopt(buf)
+		assert.Equal(t, 4, q.Cap())
+		assert.Equal(t, []int{1, 2, 0, 0, 0}, buf[:5])
+
+		q.PushSlice(3, 4)
+		assert.Equal(t, []int{1, 2, 3, 4}, q.Snapshot(nil))
+	})
+
+	t.Run("NoSpareCapacity", func(t *testing.T) {
+		t.Parallel()
+
+		buf := []int{1, 2, 3}
+		q := ring.Adopt(buf)
+		assert.Equal(t, []int{1, 2, 3}, q.Snapshot(nil))
+		assert.GreaterOrEqual(t, q.Cap(), 3)
+	})
+
+	t.Run("MuQ", func(t *testing.T) {
+		t.Parallel()
+
+		q := ring.MuQAdopt(make([]int, 3, 4))
+		assert.Equal(t, []int{0, 0, 0}, q.Snapshot(nil))
+		assert.Equal(t, 3, q.Cap())
+	})
+}
+
+func TestAdopt_noAlloc(t *testing.T) {
+	buf := make([]int, 128, 129)
+	allocs := testing.AllocsPerRun(100, func() {
+		q := ring.Adopt(buf)
+		for range q.Len() {
+			q.Push(q.Pop())
+		}
+	})
+	// Only the Q itself is allocated, not its buffer.
+	assert.LessOrEqual(t, allocs, 1.0)
+}
@@ -0,0 +1,9 @@
+//go:build !ringdebug
+
+package ring
+
+// debug enables additional checks that are too expensive
+// to run in production.
+//
+// Build with the 'ringdebug' tag to enable them.
+const debug = false
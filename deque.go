@@ -0,0 +1,161 @@
+package ring
+
+// Deque is a double-ended queue backed by a ring buffer.
+// Items may be added and removed from either end.
+// The zero value for Deque is an empty queue ready to use.
+//
+// Deque uses the same buffer layout and growth strategy as [Q].
+//
+// Deque is not safe for concurrent use.
+// If you need to use it from multiple goroutines, use [MuDeque] instead.
+type Deque[T any] struct {
+	q Q[T]
+}
+
+// NewDeque returns a new double-ended queue with the given capacity.
+// If capacity is zero, the queue is initialized with a default capacity.
+//
+// The capacity defines the leeway for bursts of pushes
+// before the queue needs to grow.
+func NewDeque[T any](capacity int) *Deque[T] {
+	var d Deque[T]
+	d.q.init(capacity)
+	return &d
+}
+
+// Empty returns true if the queue is empty.
+//
+// This is an O(1) operation and does not allocate.
+func (d *Deque[T]) Empty() bool {
+	return d.q.Empty()
+}
+
+// Len returns the number of items in the queue.
+//
+// This is an O(1) operation and does not allocate.
+func (d *Deque[T]) Len() int {
+	return d.q.Len()
+}
+
+// Clear removes all items from the queue.
+// It does not adjust its internal capacity.
+//
+// This is an O(n) operation as it zeroes out the removed items
+// so that they may be garbage collected.
+// It does not allocate.
+func (d *Deque[T]) Clear() {
+	d.q.Clear()
+}
+
+// PushFront adds x to the front of the queue.
+//
+// This operation is O(n) in the worst case if the queue needs to grow.
+// However, for target use cases, it's amortized O(1).
+// See package documentation for details.
+func (d *Deque[T]) PushFront(x T) {
+	d.q.pushFront(x)
+}
+
+// PushBack adds x to the back of the queue.
+//
+// This operation is O(n) in the worst case if the queue needs to grow.
+// However, for target use cases, it's amortized O(1).
+// See package documentation for details.
+func (d *Deque[T]) PushBack(x T) {
+	d.q.Push(x)
+}
+
+// PopFront removes and returns the item at the front of the queue.
+// It panics if the queue is empty.
+//
+// This is an O(1) operation and does not allocate.
+func (d *Deque[T]) PopFront() T {
+	x, ok := d.TryPopFront()
+	if !ok {
+		panic("empty queue")
+	}
+	return x
+}
+
+// TryPopFront removes and returns the item at the front of the queue.
+// It returns false if the queue is empty.
+// Otherwise, it returns true and the item.
+//
+// This is an O(1) operation and does not allocate.
+func (d *Deque[T]) TryPopFront() (x T, ok bool) {
+	return d.q.TryPop()
+}
+
+// PopBack removes and returns the item at the back of the queue.
+// It panics if the queue is empty.
+//
+// This is an O(1) operation and does not allocate.
+func (d *Deque[T]) PopBack() T {
+	x, ok := d.TryPopBack()
+	if !ok {
+		panic("empty queue")
+	}
+	return x
+}
+
+// TryPopBack removes and returns the item at the back of the queue.
+// It returns false if the queue is empty.
+// Otherwise, it returns true and the item.
+//
+// This is an O(1) operation and does not allocate.
+func (d *Deque[T]) TryPopBack() (x T, ok bool) {
+	return d.q.tryPopBack()
+}
+
+// PeekFront returns the item at the front of the queue without removing it.
+// It panics if the queue is empty.
+//
+// This is an O(1) operation and does not allocate.
+func (d *Deque[T]) PeekFront() T {
+	x, ok := d.TryPeekFront()
+	if !ok {
+		panic("empty queue")
+	}
+	return x
+}
+
+// TryPeekFront returns the item at the front of the queue.
+// It returns false if the queue is empty.
+// Otherwise, it returns true and the item.
+//
+// This is an O(1) operation and does not allocate.
+func (d *Deque[T]) TryPeekFront() (x T, ok bool) {
+	return d.q.TryPeek()
+}
+
+// PeekBack returns the item at the back of the queue without removing it.
+// It panics if the queue is empty.
+//
+// This is an O(1) operation and does not allocate.
+func (d *Deque[T]) PeekBack() T {
+	x, ok := d.TryPeekBack()
+	if !ok {
+		panic("empty queue")
+	}
+	return x
+}
+
+// TryPeekBack returns the item at the back of the queue.
+// It returns false if the queue is empty.
+// Otherwise, it returns true and the item.
+//
+// This is an O(1) operation and does not allocate.
+func (d *Deque[T]) TryPeekBack() (x T, ok bool) {
+	return d.q.TryBack()
+}
+
+// Snapshot appends the contents of the queue to dst and returns the result.
+// Items are appended front to back.
+//
+// Use dst to avoid allocations when you know the capacity of the queue
+// or pass nil to let the function allocate a new slice.
+//
+// The returned slice is a copy of the internal buffer and is safe to modify.
+func (d *Deque[T]) Snapshot(dst []T) []T {
+	return d.q.Snapshot(dst)
+}
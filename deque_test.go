@@ -0,0 +1,164 @@
+package ring_test
+
+import (
+	"container/list"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"go.abhg.dev/container/ring"
+	"pgregory.net/rapid"
+)
+
+type deque[T any] interface {
+	Empty() bool
+	Len() int
+	Clear()
+	PushFront(x T)
+	PushBack(x T)
+	TryPopFront() (T, bool)
+	TryPopBack() (T, bool)
+	TryPeekFront() (T, bool)
+	TryPeekBack() (T, bool)
+	Snapshot([]T) []T
+}
+
+var (
+	_ deque[int] = (*ring.Deque[int])(nil)
+	_ deque[int] = (*ring.MuDeque[int])(nil)
+)
+
+func TestDeque_empty(t *testing.T) {
+	t.Parallel()
+
+	var d ring.Deque[int]
+	assert.True(t, d.Empty(), "empty")
+	assert.Zero(t, d.Len(), "len")
+	assert.Panics(t, func() { d.PeekFront() }, "peek front")
+	assert.Panics(t, func() { d.PeekBack() }, "peek back")
+	assert.Panics(t, func() { d.PopFront() }, "pop front")
+	assert.Panics(t, func() { d.PopBack() }, "pop back")
+	assert.Empty(t, d.Snapshot(nil), "snapshot")
+}
+
+func TestDeque_PushFrontPopBack(t *testing.T) {
+	t.Parallel()
+
+	d := ring.NewDeque[int](2)
+	for i := 0; i < 10; i++ {
+		d.PushFront(i)
+	}
+	assert.Equal(t, []int{9, 8, 7, 6, 5, 4, 3, 2, 1, 0}, d.Snapshot(nil))
+
+	for i := 0; i < 10; i++ {
+		assert.Equal(t, i, d.PeekBack(), "peek back")
+		assert.Equal(t, i, d.PopBack(), "pop back")
+	}
+	assert.True(t, d.Empty(), "empty")
+}
+
+func TestDeque_rapid(t *testing.T) {
+	t.Parallel()
+
+	rapid.Check(t, func(t *rapid.T) {
+		capacity := rapid.IntRange(0, 100).Draw(t, "capacity")
+		t.Repeat(rapid.StateMachineActions(&dequeMachine{
+			d:      ring.NewDeque[int](capacity),
+			golden: list.New(),
+		}))
+	})
+}
+
+func TestMuDeque_rapid(t *testing.T) {
+	t.Parallel()
+
+	rapid.Check(t, func(t *rapid.T) {
+		capacity := rapid.IntRange(0, 100).Draw(t, "capacity")
+		t.Repeat(rapid.StateMachineActions(&dequeMachine{
+			d:      ring.NewMuDeque[int](capacity),
+			golden: list.New(),
+		}))
+	})
+}
+
+type dequeMachine struct {
+	d deque[int]
+
+	golden *list.List
+}
+
+var _ rapid.StateMachine = (*dequeMachine)(nil)
+
+func (m *dequeMachine) Check(t *rapid.T) {
+	assert.Equal(t, m.golden.Len(), m.d.Len())
+	assert.Equal(t, m.golden.Len() == 0, m.d.Empty())
+
+	got := m.d.Snapshot(nil)
+	for i, e := 0, m.golden.Front(); e != nil; i, e = i+1, e.Next() {
+		assert.Equal(t, e.Value, got[i])
+	}
+}
+
+func (m *dequeMachine) PushFront(t *rapid.T) {
+	x := rapid.Int().Draw(t, "x")
+	m.d.PushFront(x)
+	m.golden.PushFront(x)
+}
+
+func (m *dequeMachine) PushBack(t *rapid.T) {
+	x := rapid.Int().Draw(t, "x")
+	m.d.PushBack(x)
+	m.golden.PushBack(x)
+}
+
+func (m *dequeMachine) TryPopFront(t *rapid.T) {
+	got, ok := m.d.TryPopFront()
+
+	front := m.golden.Front()
+	if front == nil {
+		assert.False(t, ok)
+		return
+	}
+	assert.True(t, ok)
+	assert.Equal(t, m.golden.Remove(front), got)
+}
+
+func (m *dequeMachine) TryPopBack(t *rapid.T) {
+	got, ok := m.d.TryPopBack()
+
+	back := m.golden.Back()
+	if back == nil {
+		assert.False(t, ok)
+		return
+	}
+	assert.True(t, ok)
+	assert.Equal(t, m.golden.Remove(back), got)
+}
+
+func (m *dequeMachine) TryPeekFront(t *rapid.T) {
+	got, ok := m.d.TryPeekFront()
+
+	front := m.golden.Front()
+	if front == nil {
+		assert.False(t, ok)
+		return
+	}
+	assert.True(t, ok)
+	assert.Equal(t, front.Value, got)
+}
+
+func (m *dequeMachine) TryPeekBack(t *rapid.T) {
+	got, ok := m.d.TryPeekBack()
+
+	back := m.golden.Back()
+	if back == nil {
+		assert.False(t, ok)
+		return
+	}
+	assert.True(t, ok)
+	assert.Equal(t, back.Value, got)
+}
+
+func (m *dequeMachine) Clear(_ *rapid.T) {
+	m.d.Clear()
+	m.golden.Init()
+}
@@ -14,7 +14,9 @@
 // and where it might be useful.
 //
 // The queue is backed by a ring buffer: a slice that wraps around.
-// When the queue is full, the ring buffer grows by doubling its capacity.
+// When the queue is full, the ring buffer grows by doubling its capacity,
+// or as decided by the queue's [GrowthPolicy].
+// It does not shrink on its own unless the queue has a [ShrinkPolicy].
 //
 // A ring buffer is a slice with two pointers: head and tail.
 // The contents of the queue are usually [head:tail].
@@ -0,0 +1,205 @@
+package ring
+
+import "fmt"
+
+// Insert adds x to the queue at position i,
+// moving the items at positions i and later back by one.
+// Insert(0, x) adds x to the front of the queue,
+// and Insert(Len(), x) is the same as Push(x).
+//
+// It panics if i is out of range.
+// If the queue was created with [WithMaxCapacity]
+// and it's already full, Insert panics with [ErrFull].
+//
+// Insert moves whichever side of the queue is shorter,
+// so this is an O(min(i, Len()-i)) operation
+// in addition to the cost of growing the queue if needed.
+func (q *Q[T]) Insert(i int, x T) {
+	if n := q.Len(); i < 0 || i > n {
+		panic(fmt.Sprintf("index out of range [%d] with length %d", i, n))
+	}
+	if !q.tryInsert(i, x) {
+		panic(ErrFull)
+	}
+}
+
+// tryInsert is the implementation of Insert.
+// It returns false if the queue is at its maximum capacity.
+//
+// i must be in the range [0, Len()].
+func (q *Q[T]) tryInsert(i int, x T) bool {
+	if q.maxCap > 0 && q.Len() >= q.maxCap {
+		return false
+	}
+
+	q.mutate()
+	q.reserve(1)
+
+	n := q.Len()
+	if i < n-i {
+		// Closer to the front:
+		// move head back one slot,
+		// and shift items [0, i) towards the front.
+		if q.head == 0 {
+			q.head = len(q.buff)
+		}
+		q.head--
+		for k := 0; k < i; k++ {
+			q.buff[q.index(k)] = q.buff[q.index(k+1)]
+		}
+	} else {
+		// Closer to the back:
+		// shift items [i, n) towards the back,
+		// and move tail forward one slot.
+		for k := n; k > i; k-- {
+			q.buff[q.index(k)] = q.buff[q.index(k-1)]
+		}
+		q.tail++
+		if q.tail == len(q.buff) {
+			q.tail = 0
+		}
+	}
+	q.buff[q.index(i)] = x
+	return true
+}
+
+// Remove removes and returns the item at position i,
+// moving the items after it forward by one.
+// It panics if i is out of range.
+//
+// Remove moves whichever side of the queue is shorter,
+// so this is an O(min(i, Len()-i)) operation.
+// The removed slot is zeroed out so that the item may be garbage collected.
+func (q *Q[T]) Remove(i int) T {
+	q.checkIndex(i)
+	x := q.buff[q.index(i)]
+	q.removeRange(i, i+1)
+	return x
+}
+
+// RemoveRange removes the items at positions [i, j) from the queue,
+// moving the items after them forward.
+// It panics if the range is invalid: i < 0, j > Len(), or i > j.
+//
+// RemoveRange moves whichever side of the queue is shorter,
+// so this is an O(min(i, Len()-j)) operation.
+// The removed slots are zeroed out so that the items may be garbage collected.
+func (q *Q[T]) RemoveRange(i, j int) {
+	q.checkRange(i, j)
+	q.removeRange(i, j)
+}
+
+// removeRange is the implementation of RemoveRange.
+//
+// i and j must be in the range [0, Len()] with i <= j.
+func (q *Q[T]) removeRange(i, j int) {
+	m := j - i
+	if m == 0 {
+		return
+	}
+
+	q.mutate()
+	n := q.Len()
+	if i < n-j {
+		// Fewer items before the range than after it:
+		// shift items [0, i) towards the back by m slots,
+		// and move head forward by m slots.
+		for k := i - 1; k >= 0; k-- {
+			q.buff[q.index(k+m)] = q.buff[q.index(k)]
+		}
+		a, b := q.span(0, m)
+		clear(a)
+		clear(b)
+
+		q.head += m
+		if q.head >= len(q.buff) {
+			q.head -= len(q.buff)
+		}
+	} else {
+		// Fewer items after the range than before it:
+		// shift items [j, n) towards the front by m slots,
+		// and move tail back by m slots.
+		for k := j; k < n; k++ {
+			q.buff[q.index(k-m)] = q.buff[q.index(k)]
+		}
+		a, b := q.span(n-m, n)
+		clear(a)
+		clear(b)
+
+		q.tail -= m
+		if q.tail < 0 {
+			q.tail += len(q.buff)
+		}
+	}
+
+	q.maybeShrink()
+}
+
+// RetainFunc removes all items from the queue
+// for which keep returns false,
+// and returns the number of items removed.
+// The remaining items keep their order.
+//
+// keep is called once for each item, from front to back.
+// It must not modify the queue.
+//
+// This is an O(n) operation that does not allocate
+// unless the queue has a [ShrinkPolicy] that decides to shrink it.
+// The removed slots are zeroed out so that the items may be garbage collected.
+func (q *Q[T]) RetainFunc(keep func(T) bool) int {
+	n := q.Len()
+
+	// Compact kept items towards the front:
+	// items [0, w) have been kept so far.
+	w := 0
+	for r := 0; r < n; r++ {
+		x := q.buff[q.index(r)]
+		if !keep(x) {
+			if w == r {
+				// First removed item.
+				q.mutate()
+			}
+			continue
+		}
+		if w != r {
+			q.buff[q.index(w)] = x
+		}
+		w++
+	}
+
+	removed := n - w
+	if removed == 0 {
+		return 0
+	}
+
+	a, b := q.span(w, n)
+	clear(a)
+	clear(b)
+
+	q.tail -= removed
+	if q.tail < 0 {
+		q.tail += len(q.buff)
+	}
+	q.maybeShrink()
+	return removed
+}
+
+// DeleteFunc removes all items from the queue
+// for which del returns true,
+// and returns the number of items removed.
+// The remaining items keep their order.
+//
+// See [Q.RetainFunc] for details.
+func (q *Q[T]) DeleteFunc(del func(T) bool) int {
+	return q.RetainFunc(func(x T) bool {
+		return !del(x)
+	})
+}
+
+// checkRange panics if [i, j) is not a valid range of positions
+// in the queue.
+func (q *Q[T]) checkRange(i, j int) {
+	if n := q.Len(); i < 0 || j > n || i > j {
+		panic(fmt.Sprintf("range out of bounds [%d:%d] with length %d", i, j, n))
+	}
+}
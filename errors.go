@@ -0,0 +1,15 @@
+package ring
+
+import "errors"
+
+// ErrFull indicates that an item could not be added to a queue
+// because the queue has reached its maximum capacity.
+//
+// See [WithMaxCapacity].
+var ErrFull = errors.New("queue is full")
+
+// ErrClosed indicates that an item could not be added to a queue
+// because the queue has been closed.
+//
+// See [MuQ.Close].
+var ErrClosed = errors.New("queue is closed")
@@ -1,6 +1,7 @@
 package ring_test
 
 import (
+	"bytes"
 	"fmt"
 
 	"go.abhg.dev/container/ring"
@@ -53,3 +54,170 @@ func ExampleMuQ_TryPop_loop() {
 	// 1
 	// 2
 }
+
+func ExampleQ_Drain() {
+	type node struct {
+		name     string
+		children []*node
+	}
+
+	root := &node{
+		name: "a",
+		children: []*node{
+			{name: "b", children: []*node{{name: "d"}}},
+			{name: "c"},
+		},
+	}
+
+	var pending ring.Q[*node]
+	pending.Push(root)
+	for n := range pending.Drain() {
+		fmt.Println(n.name)
+		for _, child := range n.children {
+			pending.Push(child)
+		}
+	}
+
+	// Output:
+	// a
+	// b
+	// c
+	// d
+}
+
+func ExampleBoundedQ() {
+	recent := ring.NewBoundedQ[string](3)
+	for _, req := range []string{"/", "/about", "/login", "/home", "/logout"} {
+		recent.Push(req)
+	}
+
+	fmt.Println(recent.Snapshot(nil))
+	fmt.Println("dropped:", recent.Overwritten())
+
+	// Output:
+	// [/login /home /logout]
+	// dropped: 2
+}
+
+func ExampleWithGrowthPolicy() {
+	q := ring.NewQWithOptions[int](10, ring.WithGrowthPolicy(ring.GrowByFactor(1.5)))
+	for i := 0; i < 30; i++ {
+		q.Push(i)
+	}
+	fmt.Println(q.Cap())
+
+	// Output:
+	// 33
+}
+
+func ExampleQ_DeleteFunc() {
+	type job struct {
+		client string
+		id     int
+	}
+
+	var q ring.Q[job]
+	q.Push(job{"alice", 1})
+	q.Push(job{"bob", 2})
+	q.Push(job{"alice", 3})
+	q.Push(job{"carol", 4})
+
+	// bob disconnected.
+	removed := q.DeleteFunc(func(j job) bool {
+		return j.client == "bob"
+	})
+	fmt.Println("removed:", removed)
+	for _, j := range q.All() {
+		fmt.Println(j.client, j.id)
+	}
+
+	// Output:
+	// removed: 1
+	// alice 1
+	// alice 3
+	// carol 4
+}
+
+func ExampleQ_Rotate() {
+	var q ring.Q[string]
+	q.PushSlice("a", "b", "c", "d", "e")
+
+	q.Rotate(2)
+	fmt.Println(q.Snapshot(nil))
+
+	q.Rotate(-1)
+	fmt.Println(q.Snapshot(nil))
+
+	// Output:
+	// [c d e a b]
+	// [b c d e a]
+}
+
+func ExampleAdopt() {
+	// Leave room for one extra item
+	// so that the queue doesn't need to allocate.
+	buf := make([]int, 0, 4)
+	buf = append(buf, 1, 2, 3)
+
+	q := ring.Adopt(buf)
+	fmt.Println(q.Len(), q.Cap())
+	for !q.Empty() {
+		fmt.Println(q.Pop())
+	}
+
+	// Output:
+	// 3 3
+	// 1
+	// 2
+	// 3
+}
+
+// stringCodec encodes strings as their raw bytes.
+// The Encoder records the length of each item,
+// so the codec doesn't need to.
+type stringCodec struct{}
+
+func (stringCodec) AppendEncode(dst []byte, s string) ([]byte, error) {
+	return append(dst, s...), nil
+}
+
+func (stringCodec) Decode(data []byte) (string, error) {
+	return string(data), nil // copies data
+}
+
+func ExampleEncoder() {
+	pending := ring.FromSlice([]string{"a", "b", "c"})
+
+	var checkpoint bytes.Buffer
+	if err := ring.NewEncoder[string](&checkpoint, stringCodec{}).Encode(pending); err != nil {
+		panic(err)
+	}
+
+	var restored ring.Q[string]
+	if err := ring.NewDecoder[string](&checkpoint, stringCodec{}).Decode(&restored); err != nil {
+		panic(err)
+	}
+	fmt.Println(restored.Snapshot(nil))
+
+	// Output:
+	// [a b c]
+}
+
+func ExampleUnbounded() {
+	u := ring.NewUnbounded[int](0)
+
+	// Sends don't wait for a receiver.
+	for i := range 3 {
+		u.In() <- i
+	}
+	close(u.In())
+
+	for x := range u.Out() {
+		fmt.Println(x)
+	}
+
+	// Output:
+	// 0
+	// 1
+	// 2
+}
@@ -0,0 +1,104 @@
+package ring
+
+import (
+	"fmt"
+	"io"
+)
+
+// _formatMaxItems is the maximum number of items printed by Format
+// before the output is truncated.
+const _formatMaxItems = 100
+
+var (
+	_ fmt.Formatter = Q[int]{}
+	_ fmt.Stringer  = Q[int]{}
+
+	_ fmt.Formatter = (*MuQ[int])(nil)
+	_ fmt.Stringer  = (*MuQ[int])(nil)
+)
+
+// String returns the items in the queue, front to back,
+// formatted like a slice: "[1 2 3]".
+//
+// See [Q.Format] for details.
+func (q Q[T]) String() string {
+	return fmt.Sprint(q)
+}
+
+// Format implements [fmt.Formatter].
+// It prints the items in the queue front to back,
+// regardless of where they are in the internal buffer.
+//
+// Items are formatted like the elements of a slice:
+// each item is printed with the verb and flags given to Format.
+// For example, with items 1, 2, and 3:
+//
+//	%v   [1 2 3]
+//	%x   [1 2 3]
+//	%+v  [1 2 3] (len=3 cap=16 head=5 tail=8)
+//	%#v  ring.FromSlice([]int{1, 2, 3})
+//
+// %+v also prints the capacity of the queue
+// and the positions of its head and tail in the internal buffer.
+//
+// Only the first 100 items of the queue are printed;
+// the rest are summarized as "... +N more".
+// The %#v form is never truncated so that it remains valid Go syntax.
+func (q Q[T]) Format(f fmt.State, verb rune) {
+	q.format(f, verb, "ring.FromSlice")
+}
+
+// String returns the items in the queue, front to back,
+// formatted like a slice: "[1 2 3]".
+//
+// See [MuQ.Format] for details.
+func (q *MuQ[T]) String() string {
+	return fmt.Sprint(q)
+}
+
+// Format implements [fmt.Formatter].
+// The queue is printed under its read lock.
+//
+// With %#v, the queue is printed as a call to [MuQFromSlice].
+// See [Q.Format] for other details.
+func (q *MuQ[T]) Format(f fmt.State, verb rune) {
+	q.mu.RLock()
+	defer q.mu.RUnlock()
+	q.q.format(f, verb, "ring.MuQFromSlice")
+}
+
+// format implements Format for Q and its wrappers.
+// ctor is the name of the function used for the %#v form.
+func (q *Q[T]) format(f fmt.State, verb rune, ctor string) {
+	n := q.Len()
+	if verb == 'v' && f.Flag('#') {
+		fmt.Fprintf(f, "%s(%T{", ctor, []T(nil))
+		for i := 0; i < n; i++ {
+			if i > 0 {
+				io.WriteString(f, ", ")
+			}
+			fmt.Fprintf(f, "%#v", q.buff[q.index(i)])
+		}
+		io.WriteString(f, "})")
+		return
+	}
+
+	// Print items with the same verb, flags, width, and precision.
+	itemFormat := fmt.FormatString(f, verb)
+
+	io.WriteString(f, "[")
+	for i := 0; i < min(n, _formatMaxItems); i++ {
+		if i > 0 {
+			io.WriteString(f, " ")
+		}
+		fmt.Fprintf(f, itemFormat, q.buff[q.index(i)])
+	}
+	if n > _formatMaxItems {
+		fmt.Fprintf(f, " ... +%d more", n-_formatMaxItems)
+	}
+	io.WriteString(f, "]")
+
+	if verb == 'v' && f.Flag('+') {
+		fmt.Fprintf(f, " (len=%d cap=%d head=%d tail=%d)", n, q.Cap(), q.head, q.tail)
+	}
+}
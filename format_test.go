@@ -0,0 +1,75 @@
+package ring_test
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"go.abhg.dev/container/ring"
+)
+
+func TestQ_Format(t *testing.T) {
+	t.Parallel()
+
+	type point struct{ X, Y int }
+
+	var empty ring.Q[int]
+	points := ring.FromSlice([]point{{1, 2}, {3, 4}})
+
+	tests := []struct {
+		name   string
+		format string
+		give   any
+		want   string
+	}{
+		{"Empty", "%v", &empty, "[]"},
+		{"EmptyValue", "%v", empty, "[]"},
+		{"EmptyGoSyntax", "%#v", &empty, "ring.FromSlice([]int{})"},
+		{"Wrapped", "%v", newWrappedQ(), "[1 2 3 4 5]"},
+		{"WrappedValue", "%v", *newWrappedQ(), "[1 2 3 4 5]"},
+		{"Details", "%+v", newWrappedQ(), "[1 2 3 4 5] (len=5 cap=6 head=4 tail=2)"},
+		{"GoSyntax", "%#v", newWrappedQ(), "ring.FromSlice([]int{1, 2, 3, 4, 5})"},
+		{"ItemVerb", "%02x", ring.FromSlice([]int{10, 255}), "[0a ff]"},
+		{"Quoted", "%q", ring.FromSlice([]string{"a", "b"}), `["a" "b"]`},
+		{"StructItems", "%+v", points, "[{X:1 Y:2} {X:3 Y:4}] (len=2 cap=2 head=0 tail=2)"},
+		{
+			"StructItemsGoSyntax", "%#v", points,
+			"ring.FromSlice([]ring_test.point{ring_test.point{X:1, Y:2}, ring_test.point{X:3, Y:4}})",
+		},
+		{"MuQ", "%v", ring.MuQFromSlice([]int{1, 2}), "[1 2]"},
+		{"MuQGoSyntax", "%#v", ring.MuQFromSlice([]int{1, 2}), "ring.MuQFromSlice([]int{1, 2})"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			assert.Equal(t, tt.want, fmt.Sprintf(tt.format, tt.give))
+		})
+	}
+}
+
+func TestQ_Format_truncate(t *testing.T) {
+	t.Parallel()
+
+	q := ring.NewQ[int](0)
+	for i := range 150 {
+		q.Push(i)
+	}
+
+	got := fmt.Sprint(q)
+	assert.True(t, strings.HasPrefix(got, "[0 1 2 "), "got %q", got)
+	assert.True(t, strings.HasSuffix(got, " 98 99 ... +50 more]"), "got %q", got)
+
+	// Go syntax is never truncated.
+	got = fmt.Sprintf("%#v", q)
+	assert.True(t, strings.HasSuffix(got, ", 148, 149})"), "got %q", got)
+}
+
+func TestQ_String(t *testing.T) {
+	t.Parallel()
+
+	assert.Equal(t, "[1 2 3 4 5]", newWrappedQ().String())
+	assert.Equal(t, "[1 2]", ring.MuQFromSlice([]int{1, 2}).String())
+}
@@ -0,0 +1,109 @@
+package ring_test
+
+import (
+	"runtime"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"go.abhg.dev/container/ring"
+)
+
+func TestQ_releasesRemoved(t *testing.T) {
+	t.Parallel()
+
+	t.Run("Pop", func(t *testing.T) {
+		t.Parallel()
+
+		var q ring.Q[*gcItem]
+		testReleasesRemoved(t, q.Push, func() { q.Pop() })
+	})
+
+	t.Run("Clear", func(t *testing.T) {
+		t.Parallel()
+
+		var q ring.Q[*gcItem]
+		testReleasesRemoved(t, q.Push, q.Clear)
+	})
+}
+
+func TestMuQ_releasesRemoved(t *testing.T) {
+	t.Parallel()
+
+	t.Run("TryPop", func(t *testing.T) {
+		t.Parallel()
+
+		var q ring.MuQ[*gcItem]
+		testReleasesRemoved(t, q.Push, func() { q.TryPop() })
+	})
+
+	t.Run("Clear", func(t *testing.T) {
+		t.Parallel()
+
+		var q ring.MuQ[*gcItem]
+		testReleasesRemoved(t, q.Push, q.Clear)
+	})
+}
+
+func TestSPSC_releasesRemoved(t *testing.T) {
+	t.Parallel()
+
+	q := ring.NewSPSC[*gcItem](4)
+	testReleasesRemoved(t,
+		func(item *gcItem) { q.TryPush(item) },
+		func() { q.TryPop() },
+	)
+}
+
+func TestDeque_releasesRemoved(t *testing.T) {
+	t.Parallel()
+
+	t.Run("PopFront", func(t *testing.T) {
+		t.Parallel()
+
+		var d ring.Deque[*gcItem]
+		testReleasesRemoved(t, d.PushBack, func() { d.PopFront() })
+	})
+
+	t.Run("PopBack", func(t *testing.T) {
+		t.Parallel()
+
+		var d ring.Deque[*gcItem]
+		testReleasesRemoved(t, d.PushFront, func() { d.PopBack() })
+	})
+}
+
+// gcItem is large enough that the runtime doesn't batch it
+// with other small allocations, which would delay its finalizer.
+type gcItem struct {
+	_ [64]byte
+}
+
+// testReleasesRemoved pushes an item into a queue,
+// removes it with the given function,
+// and verifies that the queue doesn't keep it alive.
+func testReleasesRemoved(t *testing.T, push func(*gcItem), remove func()) {
+	var collected atomic.Bool
+	func() {
+		item := new(gcItem)
+		runtime.SetFinalizer(item, func(*gcItem) {
+			collected.Store(true)
+		})
+		push(item)
+	}()
+	remove()
+
+	deadline := time.Now().Add(5 * time.Second)
+	for !collected.Load() {
+		if time.Now().After(deadline) {
+			t.Error("removed item was not garbage collected")
+			break
+		}
+		runtime.GC()
+		time.Sleep(time.Millisecond)
+	}
+
+	// remove references the queue,
+	// so this keeps the queue alive until the item is collected.
+	runtime.KeepAlive(remove)
+}
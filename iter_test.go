@@ -0,0 +1,280 @@
+package ring_test
+
+import (
+	"cmp"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"go.abhg.dev/container/ring"
+)
+
+func TestQ_All(t *testing.T) {
+	t.Parallel()
+
+	// Force the contents to wrap around the end of the buffer.
+	q := ring.NewQ[int](4)
+	for i := 0; i < 3; i++ {
+		q.Push(-1)
+	}
+	for i := 0; i < 3; i++ {
+		q.Pop()
+	}
+	for i := 0; i < 4; i++ {
+		q.Push(i)
+	}
+
+	var idxs, items []int
+	for i, x := range q.All() {
+		idxs = append(idxs, i)
+		items = append(items, x)
+	}
+	assert.Equal(t, []int{0, 1, 2, 3}, idxs, "indexes")
+	assert.Equal(t, []int{0, 1, 2, 3}, items, "items")
+	assert.Equal(t, 4, q.Len(), "queue must not be modified")
+
+	idxs, items = nil, nil
+	for i, x := range q.Backward() {
+		idxs = append(idxs, i)
+		items = append(items, x)
+	}
+	assert.Equal(t, []int{3, 2, 1, 0}, idxs, "backward indexes")
+	assert.Equal(t, []int{3, 2, 1, 0}, items, "backward items")
+}
+
+func TestQ_All_break(t *testing.T) {
+	t.Parallel()
+
+	var q ring.Q[int]
+	for i := 0; i < 10; i++ {
+		q.Push(i)
+	}
+
+	var items []int
+	for _, x := range q.All() {
+		if x == 3 {
+			break
+		}
+		items = append(items, x)
+	}
+	assert.Equal(t, []int{0, 1, 2}, items)
+}
+
+func TestQ_All_empty(t *testing.T) {
+	t.Parallel()
+
+	var q ring.Q[int]
+	for range q.All() {
+		t.Fatal("unexpected item")
+	}
+	for range q.Backward() {
+		t.Fatal("unexpected item")
+	}
+	for range q.Drain() {
+		t.Fatal("unexpected item")
+	}
+}
+
+func TestQ_All_modified(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name   string
+		modify func(*ring.Q[int])
+	}{
+		{"Push", func(q *ring.Q[int]) { q.Push(42) }},
+		{"Pop", func(q *ring.Q[int]) { q.Pop() }},
+		{"Clear", (*ring.Q[int]).Clear},
+		{"PopPush", func(q *ring.Q[int]) { q.Push(q.Pop()) }},
+		{"ClearRefill", func(q *ring.Q[int]) {
+			// Ends with the same head, tail, and capacity.
+			n := q.Len()
+			q.Clear()
+			for i := range n {
+				q.Push(i + 7)
+			}
+		}},
+		{"Grow", func(q *ring.Q[int]) { q.Grow(100) }},
+		{"Reverse", (*ring.Q[int]).Reverse},
+		{"Swap", func(q *ring.Q[int]) { q.Swap(0, 1) }},
+		{"Sort", ring.Sort[int]},
+		{"SortStableFunc", func(q *ring.Q[int]) { q.SortStableFunc(cmp.Compare[int]) }},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			newQ := func() *ring.Q[int] {
+				var q ring.Q[int]
+				q.Push(1)
+				q.Push(2)
+				return &q
+			}
+
+			assert.PanicsWithValue(t, "queue modified during iteration", func() {
+				q := newQ()
+				for range q.All() {
+					tt.modify(q)
+				}
+			}, "All")
+
+			assert.PanicsWithValue(t, "queue modified during iteration", func() {
+				q := newQ()
+				for range q.Backward() {
+					tt.modify(q)
+				}
+			}, "Backward")
+		})
+	}
+}
+
+func TestQ_All_noopModification(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name   string
+		modify func(*ring.Q[int])
+	}{
+		{"TryPushFull", func(q *ring.Q[int]) { q.TryPush(42) }},
+		{"TryPushSliceFull", func(q *ring.Q[int]) { q.TryPushSlice(42) }},
+		{"TryPushSliceEmpty", func(q *ring.Q[int]) { q.TryPushSlice() }},
+		{"Grow", func(q *ring.Q[int]) { q.Grow(0) }},
+		{"GrowPastMax", func(q *ring.Q[int]) { q.Grow(100) }},
+		{"DiscardN", func(q *ring.Q[int]) { q.DiscardN(0) }},
+		{"RemoveRange", func(q *ring.Q[int]) { q.RemoveRange(1, 1) }},
+		{"RetainFunc", func(q *ring.Q[int]) { q.RetainFunc(func(int) bool { return true }) }},
+		{"ShrinkToFit", (*ring.Q[int]).ShrinkToFit},
+		{"Swap", func(q *ring.Q[int]) { q.Swap(1, 1) }},
+		{"Rotate", func(q *ring.Q[int]) { q.Rotate(2) }},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			newQ := func() *ring.Q[int] {
+				q := ring.NewQWithOptions[int](2, ring.WithMaxCapacity(2))
+				q.Push(1)
+				q.Push(2)
+				return q
+			}
+
+			var got []int
+			q := newQ()
+			for _, x := range q.All() {
+				tt.modify(q)
+				got = append(got, x)
+			}
+			assert.Equal(t, []int{1, 2}, got, "All")
+			assert.Equal(t, 2, q.Len(), "All")
+
+			got = got[:0]
+			q = newQ()
+			for _, x := range q.Backward() {
+				tt.modify(q)
+				got = append(got, x)
+			}
+			assert.Equal(t, []int{2, 1}, got, "Backward")
+			assert.Equal(t, 2, q.Len(), "Backward")
+		})
+	}
+}
+
+func TestQ_Drain(t *testing.T) {
+	t.Parallel()
+
+	var q ring.Q[int]
+	q.Push(1)
+
+	// Each item x < 4 pushes x+1 and x+2.
+	var got []int
+	for x := range q.Drain() {
+		got = append(got, x)
+		if x < 4 {
+			q.Push(x + 1)
+			q.Push(x + 2)
+		}
+	}
+	assert.Equal(t, []int{1, 2, 3, 3, 4, 4, 5, 4, 5}, got)
+	assert.True(t, q.Empty(), "empty")
+}
+
+func TestQ_Drain_break(t *testing.T) {
+	t.Parallel()
+
+	var q ring.Q[int]
+	for i := 0; i < 5; i++ {
+		q.Push(i)
+	}
+
+	for x := range q.Drain() {
+		if x == 1 {
+			break
+		}
+	}
+	assert.Equal(t, []int{2, 3, 4}, q.Snapshot(nil))
+}
+
+func TestMuQ_All(t *testing.T) {
+	t.Parallel()
+
+	var q ring.MuQ[int]
+	for i := 0; i < 3; i++ {
+		q.Push(i)
+	}
+
+	var items []int
+	for i, x := range q.All() {
+		assert.Equal(t, i, x, "index")
+		items = append(items, x)
+
+		// Modifying the queue is safe.
+		q.Push(x + 10)
+	}
+	assert.Equal(t, []int{0, 1, 2}, items)
+
+	items = nil
+	for i, x := range q.Backward() {
+		if i < 3 {
+			break
+		}
+		items = append(items, x)
+	}
+	assert.Equal(t, []int{12, 11, 10}, items)
+}
+
+func TestMuQ_Drain(t *testing.T) {
+	t.Parallel()
+
+	var q ring.MuQ[int]
+	for i := 0; i < 3; i++ {
+		q.Push(i)
+	}
+
+	var items []int
+	for x := range q.Drain() {
+		items = append(items, x)
+		if x == 0 {
+			q.Push(3)
+		}
+	}
+	assert.Equal(t, []int{0, 1, 2, 3}, items)
+	assert.True(t, q.Empty(), "empty")
+}
+
+// Verifies that modifications that leave the buffer layout unchanged
+// are still detected.
+func TestQ_All_clearRefill(t *testing.T) {
+	t.Parallel()
+
+	q := ring.FromSlice([]int{1, 2, 3})
+	var got []int
+	assert.PanicsWithValue(t, "queue modified during iteration", func() {
+		for _, x := range q.All() {
+			got = append(got, x)
+			q.Clear()
+			q.PushSlice(7, 8, 9)
+		}
+	})
+	assert.Equal(t, []int{1}, got)
+}
@@ -0,0 +1,225 @@
+package ring
+
+import (
+	"bytes"
+	"encoding"
+	"encoding/gob"
+	"encoding/json"
+	"fmt"
+)
+
+// Q and MuQ marshal as the list of items they hold, front to back.
+// The text form is the same JSON array as the JSON form,
+// for formats that encode values with [encoding.TextMarshaler].
+// Capacity and options are not part of the encoded form:
+// decoding into a queue keeps its options
+// and sizes its buffer to fit the decoded items,
+// but no smaller than the capacity the queue was created with.
+//
+// Q's marshaling methods have value receivers
+// so that a Q embedded by value in a struct is marshaled correctly
+// even if the struct isn't addressable.
+// MuQ holds a mutex and can't be copied,
+// so its methods have pointer receivers.
+
+var (
+	_ json.Marshaler             = Q[int]{}
+	_ json.Unmarshaler           = (*Q[int])(nil)
+	_ encoding.TextMarshaler     = Q[int]{}
+	_ encoding.TextUnmarshaler   = (*Q[int])(nil)
+	_ encoding.BinaryMarshaler   = Q[int]{}
+	_ encoding.BinaryUnmarshaler = (*Q[int])(nil)
+	_ gob.GobEncoder             = Q[int]{}
+	_ gob.GobDecoder             = (*Q[int])(nil)
+
+	_ json.Marshaler             = (*MuQ[int])(nil)
+	_ json.Unmarshaler           = (*MuQ[int])(nil)
+	_ encoding.TextMarshaler     = (*MuQ[int])(nil)
+	_ encoding.TextUnmarshaler   = (*MuQ[int])(nil)
+	_ encoding.BinaryMarshaler   = (*MuQ[int])(nil)
+	_ encoding.BinaryUnmarshaler = (*MuQ[int])(nil)
+	_ gob.GobEncoder             = (*MuQ[int])(nil)
+	_ gob.GobDecoder             = (*MuQ[int])(nil)
+)
+
+// MarshalJSON implements [json.Marshaler].
+// The queue is encoded as a JSON array of its items, front to back.
+func (q Q[T]) MarshalJSON() ([]byte, error) {
+	return json.Marshal(q.items())
+}
+
+// UnmarshalJSON implements [json.Unmarshaler].
+// It replaces the contents of the queue with the items in a JSON array.
+//
+// If the queue was created with [WithMaxCapacity]
+// and the array has more items than that,
+// UnmarshalJSON returns an error wrapping [ErrFull]
+// and leaves the queue unchanged.
+func (q *Q[T]) UnmarshalJSON(data []byte) error {
+	var xs []T
+	if err := json.Unmarshal(data, &xs); err != nil {
+		return err
+	}
+	return q.reset(xs)
+}
+
+// MarshalText implements [encoding.TextMarshaler].
+// It uses the same encoding as [Q.MarshalJSON].
+func (q Q[T]) MarshalText() ([]byte, error) {
+	return q.MarshalJSON()
+}
+
+// UnmarshalText implements [encoding.TextUnmarshaler].
+// It uses the same encoding as [Q.UnmarshalJSON].
+func (q *Q[T]) UnmarshalText(data []byte) error {
+	return q.UnmarshalJSON(data)
+}
+
+// MarshalBinary implements [encoding.BinaryMarshaler].
+// The items are encoded with [encoding/gob], front to back.
+func (q Q[T]) MarshalBinary() ([]byte, error) {
+	var buf bytes.Buffer
+	if err := gob.NewEncoder(&buf).Encode(q.items()); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// UnmarshalBinary implements [encoding.BinaryUnmarshaler].
+// It replaces the contents of the queue
+// with items encoded by [Q.MarshalBinary].
+//
+// As with [Q.UnmarshalJSON], it returns an error wrapping [ErrFull]
+// if the items don't fit in the queue's maximum capacity.
+func (q *Q[T]) UnmarshalBinary(data []byte) error {
+	var xs []T
+	if err := gob.NewDecoder(bytes.NewReader(data)).Decode(&xs); err != nil {
+		return err
+	}
+	return q.reset(xs)
+}
+
+// GobEncode implements [gob.GobEncoder].
+// It uses the same encoding as [Q.MarshalBinary].
+func (q Q[T]) GobEncode() ([]byte, error) {
+	return q.MarshalBinary()
+}
+
+// GobDecode implements [gob.GobDecoder].
+// It uses the same encoding as [Q.UnmarshalBinary].
+func (q *Q[T]) GobDecode(data []byte) error {
+	return q.UnmarshalBinary(data)
+}
+
+// MarshalJSON implements [json.Marshaler].
+// The queue is encoded under its read lock.
+//
+// See [Q.MarshalJSON] for details.
+func (q *MuQ[T]) MarshalJSON() ([]byte, error) {
+	q.mu.RLock()
+	defer q.mu.RUnlock()
+	return q.q.MarshalJSON()
+}
+
+// UnmarshalJSON implements [json.Unmarshaler].
+// It returns [ErrClosed] if the queue is closed.
+//
+// See [Q.UnmarshalJSON] for details.
+func (q *MuQ[T]) UnmarshalJSON(data []byte) error {
+	// Decode outside the lock; only swapping the contents needs it.
+	var xs []T
+	if err := json.Unmarshal(data, &xs); err != nil {
+		return err
+	}
+
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	if q.closed {
+		return ErrClosed
+	}
+	if err := q.q.reset(xs); err != nil {
+		return err
+	}
+	q.added(len(xs))
+	return nil
+}
+
+// MarshalText implements [encoding.TextMarshaler].
+// It uses the same encoding as [MuQ.MarshalJSON].
+func (q *MuQ[T]) MarshalText() ([]byte, error) {
+	return q.MarshalJSON()
+}
+
+// UnmarshalText implements [encoding.TextUnmarshaler].
+// It uses the same encoding as [MuQ.UnmarshalJSON].
+func (q *MuQ[T]) UnmarshalText(data []byte) error {
+	return q.UnmarshalJSON(data)
+}
+
+// MarshalBinary implements [encoding.BinaryMarshaler].
+// The queue is encoded under its read lock.
+//
+// See [Q.MarshalBinary] for details.
+func (q *MuQ[T]) MarshalBinary() ([]byte, error) {
+	q.mu.RLock()
+	defer q.mu.RUnlock()
+	return q.q.MarshalBinary()
+}
+
+// UnmarshalBinary implements [encoding.BinaryUnmarshaler].
+// It returns [ErrClosed] if the queue is closed.
+//
+// See [Q.UnmarshalBinary] for details.
+func (q *MuQ[T]) UnmarshalBinary(data []byte) error {
+	var xs []T
+	if err := gob.NewDecoder(bytes.NewReader(data)).Decode(&xs); err != nil {
+		return err
+	}
+
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	if q.closed {
+		return ErrClosed
+	}
+	if err := q.q.reset(xs); err != nil {
+		return err
+	}
+	q.added(len(xs))
+	return nil
+}
+
+// GobEncode implements [gob.GobEncoder].
+// It uses the same encoding as [MuQ.MarshalBinary].
+func (q *MuQ[T]) GobEncode() ([]byte, error) {
+	return q.MarshalBinary()
+}
+
+// GobDecode implements [gob.GobDecoder].
+// It uses the same encoding as [MuQ.UnmarshalBinary].
+func (q *MuQ[T]) GobDecode(data []byte) error {
+	return q.UnmarshalBinary(data)
+}
+
+// items returns the items in the queue, front to back.
+// Unlike Snapshot, it never returns nil,
+// so an empty queue encodes as an empty list.
+func (q *Q[T]) items() []T {
+	return q.Snapshot(make([]T, 0, q.Len()))
+}
+
+// reset replaces the contents of the queue with xs,
+// sizing the buffer to fit them,
+// but no smaller than the capacity the queue was created with.
+// The queue's options and that minimum capacity are retained.
+func (q *Q[T]) reset(xs []T) error {
+	if q.maxCap > 0 && len(xs) > q.maxCap {
+		return fmt.Errorf("decode %d items with maximum capacity %d: %w", len(xs), q.maxCap, ErrFull)
+	}
+
+	q.mutate()
+	minCap := q.minCap
+	q.init(max(len(xs), minCap))
+	q.minCap = minCap
+	q.PushSlice(xs...)
+	return nil
+}
@@ -0,0 +1,227 @@
+package ring_test
+
+import (
+	"bytes"
+	"encoding/gob"
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.abhg.dev/container/ring"
+)
+
+func TestQ_MarshalJSON(t *testing.T) {
+	t.Parallel()
+
+	t.Run("Empty", func(t *testing.T) {
+		t.Parallel()
+
+		var q ring.Q[int]
+		data, err := json.Marshal(&q)
+		require.NoError(t, err)
+		assert.JSONEq(t, `[]`, string(data))
+	})
+
+	t.Run("Wrapped", func(t *testing.T) {
+		t.Parallel()
+
+		data, err := json.Marshal(newWrappedQ())
+		require.NoError(t, err)
+		assert.JSONEq(t, `[1, 2, 3, 4, 5]`, string(data))
+	})
+
+	t.Run("ValueField", func(t *testing.T) {
+		t.Parallel()
+
+		type state struct {
+			Pending ring.Q[string] `json:"pending"`
+		}
+
+		var s state
+		s.Pending.PushSlice("a", "b")
+
+		// Passed by value: the struct isn't addressable.
+		data, err := json.Marshal(s)
+		require.NoError(t, err)
+		assert.JSONEq(t, `{"pending": ["a", "b"]}`, string(data))
+
+		var got state
+		require.NoError(t, json.Unmarshal(data, &got))
+		assert.Equal(t, []string{"a", "b"}, got.Pending.Snapshot(nil))
+	})
+}
+
+func TestQ_UnmarshalJSON(t *testing.T) {
+	t.Parallel()
+
+	t.Run("ReplacesContents", func(t *testing.T) {
+		t.Parallel()
+
+		q := newWrappedQ()
+		require.NoError(t, json.Unmarshal([]byte(`[6, 7]`), q))
+		assert.Equal(t, []int{6, 7}, q.Snapshot(nil))
+		assert.Equal(t, 6, q.Cap(), "capacity the queue was created with")
+
+		q.PushSlice(8, 9)
+		assert.Equal(t, []int{6, 7, 8, 9}, q.Snapshot(nil))
+	})
+
+	t.Run("Null", func(t *testing.T) {
+		t.Parallel()
+
+		q := newWrappedQ()
+		require.NoError(t, json.Unmarshal([]byte(`null`), q))
+		assert.True(t, q.Empty())
+	})
+
+	t.Run("Invalid", func(t *testing.T) {
+		t.Parallel()
+
+		q := newWrappedQ()
+		assert.Error(t, json.Unmarshal([]byte(`{"a": 1}`), q))
+		assert.Equal(t, []int{1, 2, 3, 4, 5}, q.Snapshot(nil))
+	})
+
+	t.Run("MaxCapacity", func(t *testing.T) {
+		t.Parallel()
+
+		q := ring.NewQWithOptions[int](2, ring.WithMaxCapacity(2))
+		q.Push(1)
+
+		err := json.Unmarshal([]byte(`[1, 2, 3]`), q)
+		assert.ErrorIs(t, err, ring.ErrFull)
+		assert.Equal(t, []int{1}, q.Snapshot(nil))
+
+		// Options survive decoding.
+		require.NoError(t, json.Unmarshal([]byte(`[3, 4]`), q))
+		assert.False(t, q.TryPush(5))
+	})
+}
+
+func TestQ_UnmarshalJSON_keepsOptions(t *testing.T) {
+	t.Parallel()
+
+	q := ring.NewQWithOptions[int](64, ring.WithShrinkPolicy(ring.ShrinkHalfAtQuarter))
+	require.NoError(t, json.Unmarshal([]byte(`[1]`), q))
+	assert.Equal(t, []int{1}, q.Snapshot(nil))
+	assert.Equal(t, 64, q.Cap(), "capacity the queue was created with")
+
+	// Grow past the original capacity and drain:
+	// the queue shrinks back down to its original capacity, not below.
+	for i := range 200 {
+		q.Push(i)
+	}
+	q.Clear()
+	assert.Equal(t, 64, q.Cap())
+
+	// Decoding more items than the original capacity fits them.
+	data, err := json.Marshal(make([]int, 100))
+	require.NoError(t, err)
+	require.NoError(t, json.Unmarshal(data, q))
+	assert.Equal(t, 100, q.Len())
+	assert.GreaterOrEqual(t, q.Cap(), 100)
+}
+
+func TestMuQ_UnmarshalBinary_keepsOptions(t *testing.T) {
+	t.Parallel()
+
+	data, err := ring.FromSlice([]int{1}).MarshalBinary()
+	require.NoError(t, err)
+
+	q := ring.NewMuQWithOptions[int](64, ring.WithShrinkPolicy(ring.ShrinkHalfAtQuarter))
+	require.NoError(t, q.UnmarshalBinary(data))
+	assert.Equal(t, []int{1}, q.Snapshot(nil))
+	assert.Equal(t, 64, q.Cap())
+}
+
+func TestQ_MarshalText(t *testing.T) {
+	t.Parallel()
+
+	data, err := newWrappedQ().MarshalText()
+	require.NoError(t, err)
+	assert.JSONEq(t, `[1, 2, 3, 4, 5]`, string(data))
+
+	var q ring.Q[int]
+	require.NoError(t, q.UnmarshalText(data))
+	assert.Equal(t, []int{1, 2, 3, 4, 5}, q.Snapshot(nil))
+
+	assert.Error(t, q.UnmarshalText([]byte("not json")))
+	assert.Equal(t, []int{1, 2, 3, 4, 5}, q.Snapshot(nil))
+}
+
+func TestQ_MarshalBinary(t *testing.T) {
+	t.Parallel()
+
+	data, err := newWrappedQ().MarshalBinary()
+	require.NoError(t, err)
+
+	var q ring.Q[int]
+	require.NoError(t, q.UnmarshalBinary(data))
+	assert.Equal(t, []int{1, 2, 3, 4, 5}, q.Snapshot(nil))
+
+	assert.Error(t, q.UnmarshalBinary([]byte("not gob")))
+	assert.Equal(t, []int{1, 2, 3, 4, 5}, q.Snapshot(nil))
+}
+
+func TestQ_gob(t *testing.T) {
+	t.Parallel()
+
+	type state struct {
+		Name    string
+		Pending ring.Q[string]
+	}
+
+	give := state{Name: "foo"}
+	give.Pending.PushSlice("a", "b", "c")
+
+	var buf bytes.Buffer
+	require.NoError(t, gob.NewEncoder(&buf).Encode(give))
+
+	var got state
+	require.NoError(t, gob.NewDecoder(&buf).Decode(&got))
+	assert.Equal(t, "foo", got.Name)
+	assert.Equal(t, []string{"a", "b", "c"}, got.Pending.Snapshot(nil))
+}
+
+func TestMuQ_marshal(t *testing.T) {
+	t.Parallel()
+
+	var q ring.MuQ[int]
+	q.PushSlice(1, 2, 3)
+
+	t.Run("JSON", func(t *testing.T) {
+		t.Parallel()
+
+		data, err := json.Marshal(&q)
+		require.NoError(t, err)
+		assert.JSONEq(t, `[1, 2, 3]`, string(data))
+
+		var got ring.MuQ[int]
+		require.NoError(t, json.Unmarshal(data, &got))
+		assert.Equal(t, []int{1, 2, 3}, got.Snapshot(nil))
+	})
+
+	t.Run("Text", func(t *testing.T) {
+		t.Parallel()
+
+		data, err := q.MarshalText()
+		require.NoError(t, err)
+		assert.JSONEq(t, `[1, 2, 3]`, string(data))
+
+		var got ring.MuQ[int]
+		require.NoError(t, got.UnmarshalText(data))
+		assert.Equal(t, []int{1, 2, 3}, got.Snapshot(nil))
+	})
+
+	t.Run("Gob", func(t *testing.T) {
+		t.Parallel()
+
+		var buf bytes.Buffer
+		require.NoError(t, gob.NewEncoder(&buf).Encode(&q))
+
+		var got ring.MuQ[int]
+		require.NoError(t, gob.NewDecoder(&buf).Decode(&got))
+		assert.Equal(t, []int{1, 2, 3}, got.Snapshot(nil))
+	})
+}
@@ -0,0 +1,133 @@
+package ring
+
+import "sync"
+
+// MuDeque is a thread-safe double-ended queue backed by a ring buffer.
+// The zero value for MuDeque is an empty queue ready to use.
+//
+// MuDeque is safe for concurrent use.
+// If you need to use it from a single goroutine, use [Deque] instead.
+type MuDeque[T any] struct {
+	mu sync.RWMutex
+	d  Deque[T]
+}
+
+// Like MuQ, MuDeque has only the Try variants of Pop and Peek methods.
+// See the comment on MuQ for the rationale.
+
+// NewMuDeque returns a new thread-safe double-ended queue
+// with the given capacity.
+func NewMuDeque[T any](capacity int) *MuDeque[T] {
+	var m MuDeque[T]
+	m.d.q.init(capacity)
+	return &m
+}
+
+// Empty returns true if the queue is empty.
+//
+// This is an O(1) operation and does not allocate.
+func (d *MuDeque[T]) Empty() bool {
+	d.mu.RLock()
+	defer d.mu.RUnlock()
+	return d.d.Empty()
+}
+
+// Len returns the number of items in the queue.
+//
+// This is an O(1) operation and does not allocate.
+func (d *MuDeque[T]) Len() int {
+	d.mu.RLock()
+	defer d.mu.RUnlock()
+	return d.d.Len()
+}
+
+// Clear removes all items from the queue.
+// It does not adjust its internal capacity.
+//
+// This is an O(n) operation as it zeroes out the removed items
+// so that they may be garbage collected.
+// It does not allocate.
+func (d *MuDeque[T]) Clear() {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	d.d.Clear()
+}
+
+// PushFront adds x to the front of the queue.
+//
+// This operation is O(n) in the worst case if the queue needs to grow.
+// However, for target use cases, it's amortized O(1).
+// See package documentation for details.
+func (d *MuDeque[T]) PushFront(x T) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	d.d.PushFront(x)
+}
+
+// PushBack adds x to the back of the queue.
+//
+// This operation is O(n) in the worst case if the queue needs to grow.
+// However, for target use cases, it's amortized O(1).
+// See package documentation for details.
+func (d *MuDeque[T]) PushBack(x T) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	d.d.PushBack(x)
+}
+
+// TryPopFront removes and returns the item at the front of the queue.
+// It returns false if the queue is empty.
+// Otherwise, it returns true and the item.
+//
+// This is an O(1) operation and does not allocate.
+func (d *MuDeque[T]) TryPopFront() (x T, ok bool) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	return d.d.TryPopFront()
+}
+
+// TryPopBack removes and returns the item at the back of the queue.
+// It returns false if the queue is empty.
+// Otherwise, it returns true and the item.
+//
+// This is an O(1) operation and does not allocate.
+func (d *MuDeque[T]) TryPopBack() (x T, ok bool) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	return d.d.TryPopBack()
+}
+
+// TryPeekFront returns the item at the front of the queue.
+// It returns false if the queue is empty.
+// Otherwise, it returns true and the item.
+//
+// This is an O(1) operation and does not allocate.
+func (d *MuDeque[T]) TryPeekFront() (x T, ok bool) {
+	d.mu.RLock()
+	defer d.mu.RUnlock()
+	return d.d.TryPeekFront()
+}
+
+// TryPeekBack returns the item at the back of the queue.
+// It returns false if the queue is empty.
+// Otherwise, it returns true and the item.
+//
+// This is an O(1) operation and does not allocate.
+func (d *MuDeque[T]) TryPeekBack() (x T, ok bool) {
+	d.mu.RLock()
+	defer d.mu.RUnlock()
+	return d.d.TryPeekBack()
+}
+
+// Snapshot appends the contents of the queue to dst and returns the result.
+// Items are appended front to back.
+//
+// Use dst to avoid allocations when you know the capacity of the queue
+// or pass nil to let the function allocate a new slice.
+//
+// The returned slice is a copy of the internal buffer and is safe to modify.
+func (d *MuDeque[T]) Snapshot(dst []T) []T {
+	d.mu.RLock()
+	defer d.mu.RUnlock()
+	return d.d.Snapshot(dst)
+}
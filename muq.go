@@ -1,6 +1,10 @@
 package ring
 
-import "sync"
+import (
+	"iter"
+	"sync"
+	"sync/atomic"
+)
 
 // MuQ is a thread-safe FIFO queue backed by a ring buffer.
 // The zero value for MuQ is an empty queue ready to use.
@@ -10,6 +14,39 @@ import "sync"
 type MuQ[T any] struct {
 	mu sync.RWMutex
 	q  Q[T]
+
+	// waiters holds a channel for each goroutine blocked in PopWait,
+	// in the order they started waiting.
+	// Adding an item to q closes the channel at the front.
+	waiters Q[chan struct{}]
+
+	// closed is set by Close.
+	// Once set, no more items may be added to q.
+	closed bool
+
+	// ready is the channel returned by Ready, created on first use.
+	// It has a buffer of one, and holds a value
+	// if the queue may have become non-empty since it was last received from.
+	ready chan struct{}
+
+	// id orders this queue's lock relative to other queues
+	// in methods that lock two queues.
+	// It's assigned on first use; see lockID.
+	id atomic.Uint64
+}
+
+// muqIDs is the source of MuQ lock IDs.
+var muqIDs atomic.Uint64
+
+// lockID returns a number unique to this queue,
+// assigning it on first use.
+func (q *MuQ[T]) lockID() uint64 {
+	if id := q.id.Load(); id != 0 {
+		return id
+	}
+	// If another goroutine assigns an ID first, use that one.
+	q.id.CompareAndSwap(0, muqIDs.Add(1))
+	return q.id.Load()
 }
 
 // The API for MuQ differs from Q somewhat:
@@ -20,6 +57,26 @@ type MuQ[T any] struct {
 // must be a single atomic operation,
 // which means users will only need Try variants,
 // and having the panicking versions will just cause bugs.
+//
+// The same applies to methods that access items by position:
+// the position must be checked against the length of the queue
+// under the same lock as the access,
+// so MuQ has only TryAt, TrySet, etc., not At, Set, etc.
+//
+// Methods that add items to the queue
+// panic with ErrClosed (or return false for Try variants)
+// after the queue has been closed.
+// The Try variants return false both when the queue is closed
+// and when it's at its maximum capacity.
+// Checking Closed() after a Try variant fails does not tell the two apart:
+// the queue may have been closed after the push failed because it was full.
+// Use PushErr, which reports ErrClosed or ErrFull,
+// to find out why an item was not added.
+//
+// Likewise, checking Closed() after TryPop reports an empty queue
+// does not mean that the queue has been drained:
+// items may have been pushed right before it was closed.
+// Use TryPopClosed, which checks both under the same lock.
 
 // NewMuQ returns a new thread-safe queue with the given capacity.
 func NewMuQ[T any](capacity int) *MuQ[T] {
@@ -46,39 +103,277 @@ func (q *MuQ[T]) Len() int {
 	return q.q.Len()
 }
 
-// Clear removes all items from the queue.
-// It does not adjust its internal capacity.
+// Cap returns the number of items the queue can hold
+// without growing.
 //
 // This is an O(1) operation and does not allocate.
+func (q *MuQ[T]) Cap() int {
+	q.mu.RLock()
+	defer q.mu.RUnlock()
+	return q.q.Cap()
+}
+
+// Grow increases the capacity of the queue, if necessary,
+// to guarantee space for n more items.
+// It panics if n is negative.
+//
+// See [Q.Grow] for details.
+// Note that concurrent pushes from other goroutines
+// may use up the reserved space.
+func (q *MuQ[T]) Grow(n int) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	q.q.Grow(n)
+}
+
+// Clear removes all items from the queue.
+// It does not adjust its internal capacity
+// unless the queue has a [ShrinkPolicy].
+//
+// This is an O(n) operation as it zeroes out the removed items
+// so that they may be garbage collected.
+// It does not allocate
+// unless the queue has a [ShrinkPolicy] that decides to shrink it.
 func (q *MuQ[T]) Clear() {
 	q.mu.Lock()
 	defer q.mu.Unlock()
 	q.q.Clear()
 }
 
+// Close closes the queue, preventing new items from being added.
+// Items already in the queue remain and may still be removed,
+// so consumers can drain the queue for a graceful shutdown.
+//
+// After Close, Push and other methods that add items panic with [ErrClosed],
+// their Try variants return false,
+// and [MuQ.PushErr] returns [ErrClosed].
+// Goroutines blocked in PopWait are woken up;
+// PopWait returns [ErrClosed] once the queue is empty.
+// The channel returned by Ready is signaled.
+//
+// Calling Close more than once has no effect.
+func (q *MuQ[T]) Close() {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	if q.closed {
+		return
+	}
+	q.closed = true
+	q.notify(q.waiters.Len())
+	q.signalReady()
+}
+
+// Closed reports whether Close has been called on the queue.
+//
+// To check whether a closed queue has been drained, use TryPopClosed:
+// items may be pushed between a failed TryPop and the call to Closed.
+//
+// This is an O(1) operation and does not allocate.
+func (q *MuQ[T]) Closed() bool {
+	q.mu.RLock()
+	defer q.mu.RUnlock()
+	return q.closed
+}
+
 // Push adds x to the back of the queue.
+// If the queue was created with [WithMaxCapacity]
+// and it's already full, Push panics with [ErrFull].
+// If the queue is closed, Push panics with [ErrClosed].
+// Use [MuQ.PushErr] for queues with a maximum capacity
+// or queues that may be closed concurrently.
 //
 // This operation is O(n) in the worst case if the queue needs to grow.
 // However, for target use cases, it's amortized O(1).
 // See package documentation for details.
-// If your usage pattern is bursts of pushes followed by bursts of pops,
 func (q *MuQ[T]) Push(x T) {
+	if err := q.PushErr(x); err != nil {
+		panic(err)
+	}
+}
+
+// PushErr adds x to the back of the queue.
+// It returns [ErrClosed] if the queue is closed,
+// or [ErrFull] if it was created with [WithMaxCapacity]
+// and it's already full.
+// Otherwise, it returns nil.
+//
+//	if err := q.PushErr(x); errors.Is(err, ring.ErrClosed) {
+//		return err // stop producing
+//	}
+//
+// This operation is O(n) in the worst case if the queue needs to grow.
+// However, for target use cases, it's amortized O(1).
+// See package documentation for details.
+func (q *MuQ[T]) PushErr(x T) error {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	return q.tryPush(x)
+}
+
+// TryPush adds x to the back of the queue.
+// It returns false if the queue is closed,
+// or if it was created with [WithMaxCapacity] and it's already full.
+// Otherwise, it returns true.
+// Use [MuQ.PushErr] to tell the two apart.
+//
+// This operation is O(n) in the worst case if the queue needs to grow.
+// However, for target use cases, it's amortized O(1).
+// See package documentation for details.
+func (q *MuQ[T]) TryPush(x T) bool {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	return q.tryPush(x) == nil
+}
+
+// tryPush implements PushErr and TryPush.
+//
+// The caller must hold the write lock.
+func (q *MuQ[T]) tryPush(x T) error {
+	if q.closed {
+		return ErrClosed
+	}
+	if !q.q.TryPush(x) {
+		return ErrFull
+	}
+	q.added(1)
+	return nil
+}
+
+// PushSlice adds the items in xs to the back of the queue, in order.
+// If the queue was created with [WithMaxCapacity]
+// and it doesn't have room for all items,
+// PushSlice panics with [ErrFull] without adding any of them.
+// If the queue is closed, PushSlice panics with [ErrClosed].
+// Use TryPushSlice for queues with a maximum capacity
+// or queues that may be closed concurrently.
+//
+// All items are added under a single lock,
+// so other goroutines see either none or all of them.
+func (q *MuQ[T]) PushSlice(xs ...T) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	if q.closed {
+		panic(ErrClosed)
+	}
+	q.q.PushSlice(xs...)
+	q.added(len(xs))
+}
+
+// TryPushSlice adds the items in xs to the back of the queue, in order.
+// It returns false without adding any items
+// if the queue is closed,
+// or if it was created with [WithMaxCapacity]
+// and it doesn't have room for all of them.
+// Otherwise, it returns true.
+//
+// All items are added under a single lock,
+// so other goroutines see either none or all of them.
+func (q *MuQ[T]) TryPushSlice(xs ...T) bool {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	if q.closed || !q.q.TryPushSlice(xs...) {
+		return false
+	}
+	q.added(len(xs))
+	return true
+}
+
+// PopN removes up to n items from the front of the queue,
+// appends them to dst in order, and returns the result.
+// If the queue has fewer than n items, all of them are removed.
+// It panics if n is negative.
+//
+// All items are removed under a single lock.
+func (q *MuQ[T]) PopN(dst []T, n int) []T {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	return q.q.PopN(dst, n)
+}
+
+// PeekN appends up to n items from the front of the queue to dst
+// without removing them, and returns the result.
+// If the queue has fewer than n items, all of them are appended.
+// It panics if n is negative.
+//
+// The returned slice is a copy of the internal buffer and is safe to modify.
+func (q *MuQ[T]) PeekN(dst []T, n int) []T {
+	q.mu.RLock()
+	defer q.mu.RUnlock()
+	return q.q.PeekN(dst, n)
+}
+
+// DiscardN removes up to n items from the front of the queue
+// without returning them.
+// It returns the number of items removed,
+// which is less than n only if the queue has fewer than n items.
+// It panics if n is negative.
+func (q *MuQ[T]) DiscardN(n int) int {
 	q.mu.Lock()
 	defer q.mu.Unlock()
-	q.q.Push(x)
+	return q.q.DiscardN(n)
 }
 
 // TryPop removes and returns the item at the front of the queue.
 // It returns false if the queue is empty.
 // Otherwise, it returns true and the item.
 //
-// This is an O(1) operation and does not allocate.
+// This is an O(1) operation and does not allocate
+// unless the queue has a [ShrinkPolicy] that decides to shrink it.
 func (q *MuQ[T]) TryPop() (x T, ok bool) {
 	q.mu.Lock()
 	defer q.mu.Unlock()
 	return q.q.TryPop()
 }
 
+// TryPopClosed removes and returns the item at the front of the queue,
+// and reports whether the queue is closed.
+// It returns false if the queue is empty.
+// Otherwise, it returns true and the item.
+//
+// Both are checked under the same lock,
+// so if it returns false for ok and true for closed,
+// the queue is empty and will never have items again.
+// Use it to drain a queue until it's closed:
+//
+//	for {
+//		job, ok, closed := jobs.TryPopClosed()
+//		if ok {
+//			job.Run()
+//			continue
+//		}
+//		if closed {
+//			return // drained
+//		}
+//		// Wait for more items, e.g. with Ready.
+//	}
+//
+// Do not call Closed after TryPop reports an empty queue instead:
+// an item may be pushed and the queue closed between the two calls,
+// and that item would never be removed.
+//
+// This is an O(1) operation and does not allocate
+// unless the queue has a [ShrinkPolicy] that decides to shrink it.
+func (q *MuQ[T]) TryPopClosed() (x T, ok, closed bool) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	x, ok = q.q.TryPop()
+	return x, ok, q.closed
+}
+
+// ShrinkToFit reduces the capacity of the queue
+// to the number of items in it, releasing unused memory.
+// If the queue is empty, all memory held by it is released.
+//
+// This ignores the queue's [ShrinkPolicy] and its initial capacity.
+//
+// This is an O(n) operation that allocates
+// if the queue has unused capacity.
+func (q *MuQ[T]) ShrinkToFit() {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	q.q.ShrinkToFit()
+}
+
 // TryPeek returns the item at the front of the queue.
 // It returns false if the queue is empty.
 // Otherwise, it returns true and the item.
@@ -90,6 +385,236 @@ func (q *MuQ[T]) TryPeek() (x T, ok bool) {
 	return q.q.TryPeek()
 }
 
+// TryBack returns the item at the back of the queue.
+// This is the most recently pushed item.
+// It returns false if the queue is empty.
+// Otherwise, it returns true and the item.
+//
+// This is an O(1) operation and does not allocate.
+func (q *MuQ[T]) TryBack() (x T, ok bool) {
+	q.mu.RLock()
+	defer q.mu.RUnlock()
+	return q.q.TryBack()
+}
+
+// TryAt returns the item at position i in the queue.
+// Position 0 is the front of the queue,
+// and position Len()-1 is the back.
+// It returns false if i is out of range.
+// Otherwise, it returns true and the item.
+//
+// This is an O(1) operation and does not allocate.
+func (q *MuQ[T]) TryAt(i int) (x T, ok bool) {
+	q.mu.RLock()
+	defer q.mu.RUnlock()
+	return q.q.TryAt(i)
+}
+
+// TrySet replaces the item at position i in the queue with x.
+// It returns false if i is out of range.
+//
+// This is an O(1) operation and does not allocate.
+func (q *MuQ[T]) TrySet(i int, x T) bool {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	if i < 0 || i >= q.q.Len() {
+		return false
+	}
+	q.q.Set(i, x)
+	return true
+}
+
+// TryInsert adds x to the queue at position i,
+// moving the items at positions i and later back by one.
+// It returns false if i is out of range [0, Len()],
+// if the queue is closed,
+// or if the queue was created with [WithMaxCapacity] and it's already full.
+// Otherwise, it returns true.
+//
+// See [Q.Insert] for details.
+func (q *MuQ[T]) TryInsert(i int, x T) bool {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	if q.closed || i < 0 || i > q.q.Len() || !q.q.tryInsert(i, x) {
+		return false
+	}
+	q.added(1)
+	return true
+}
+
+// TryRemove removes and returns the item at position i,
+// moving the items after it forward by one.
+// It returns false if i is out of range.
+// Otherwise, it returns true and the item.
+//
+// See [Q.Remove] for details.
+func (q *MuQ[T]) TryRemove(i int) (x T, ok bool) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	if i < 0 || i >= q.q.Len() {
+		return x, false
+	}
+	return q.q.Remove(i), true
+}
+
+// TryRemoveRange removes the items at positions [i, j) from the queue,
+// moving the items after them forward.
+// It returns false without removing anything if the range is invalid:
+// i < 0, j > Len(), or i > j.
+// Otherwise, it returns true.
+//
+// See [Q.RemoveRange] for details.
+func (q *MuQ[T]) TryRemoveRange(i, j int) bool {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	if i < 0 || j > q.q.Len() || i > j {
+		return false
+	}
+	q.q.removeRange(i, j)
+	return true
+}
+
+// RetainFunc removes all items from the queue
+// for which keep returns false,
+// and returns the number of items removed.
+// The remaining items keep their order.
+//
+// The queue is locked for the duration of the call,
+// so keep must not call methods on the queue.
+//
+// See [Q.RetainFunc] for details.
+func (q *MuQ[T]) RetainFunc(keep func(T) bool) int {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	return q.q.RetainFunc(keep)
+}
+
+// DeleteFunc removes all items from the queue
+// for which del returns true,
+// and returns the number of items removed.
+// The remaining items keep their order.
+//
+// The queue is locked for the duration of the call,
+// so del must not call methods on the queue.
+//
+// See [Q.RetainFunc] for details.
+func (q *MuQ[T]) DeleteFunc(del func(T) bool) int {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	return q.q.DeleteFunc(del)
+}
+
+// Rotate moves the first n items of the queue to the back,
+// keeping their order.
+// If n is negative, it moves the last -n items to the front instead.
+//
+// The whole rotation happens under a single lock.
+// See [Q.Rotate] for details.
+func (q *MuQ[T]) Rotate(n int) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	q.q.Rotate(n)
+}
+
+// Reverse reverses the order of the items in the queue in place.
+//
+// This is an O(n) operation and does not allocate.
+func (q *MuQ[T]) Reverse() {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	q.q.Reverse()
+}
+
+// TrySwap swaps the items at positions i and j in the queue.
+// It returns false if i or j are out of range.
+// Otherwise, it returns true.
+//
+// This is an O(1) operation and does not allocate.
+func (q *MuQ[T]) TrySwap(i, j int) bool {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	if n := q.q.Len(); i < 0 || i >= n || j < 0 || j >= n {
+		return false
+	}
+	q.q.swap(i, j)
+	return true
+}
+
+// SortFunc sorts the items in the queue in ascending order
+// as determined by the cmp function.
+// The sort is not guaranteed to be stable.
+//
+// The queue is locked for the duration of the call,
+// so cmp must not call methods on the queue.
+// See [Q.SortFunc] for details.
+func (q *MuQ[T]) SortFunc(cmp func(a, b T) int) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	q.q.SortFunc(cmp)
+}
+
+// SortStableFunc sorts the items in the queue
+// as determined by the cmp function,
+// keeping the original order of equal items.
+//
+// The queue is locked for the duration of the call,
+// so cmp must not call methods on the queue.
+// See [Q.SortStableFunc] for details.
+func (q *MuQ[T]) SortStableFunc(cmp func(a, b T) int) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	q.q.SortStableFunc(cmp)
+}
+
+// Clone returns a copy of the queue.
+// The copy has its own buffer with the same capacity as the original,
+// and the same options (e.g. [WithMaxCapacity]) as the original.
+// The copy is not closed, even if the original is.
+//
+// See [Q.Clone] for details.
+func (q *MuQ[T]) Clone() *MuQ[T] {
+	q.mu.RLock()
+	defer q.mu.RUnlock()
+	return &MuQ[T]{q: *q.q.Clone()}
+}
+
+// AppendQueue moves all items from other to the back of q,
+// keeping their order, and leaves other empty.
+// If other is q, AppendQueue does nothing.
+//
+// If q is closed, AppendQueue panics with [ErrClosed]
+// without moving any items.
+// other may be closed: its items are moved out as if drained.
+//
+// Both queues are locked for the duration of the call,
+// so other goroutines see the items in exactly one of the two queues.
+// Locks are always acquired in the same order,
+// so concurrent calls to q.AppendQueue(other) and other.AppendQueue(q)
+// don't deadlock.
+//
+// See [Q.AppendQueue] for details.
+func (q *MuQ[T]) AppendQueue(other *MuQ[T]) {
+	if q == other {
+		return
+	}
+
+	first, second := q, other
+	if other.lockID() < q.lockID() {
+		first, second = other, q
+	}
+	first.mu.Lock()
+	defer first.mu.Unlock()
+	second.mu.Lock()
+	defer second.mu.Unlock()
+
+	if q.closed {
+		panic(ErrClosed)
+	}
+	n := other.q.Len()
+	q.q.AppendQueue(&other.q)
+	q.added(n)
+}
+
 // Snapshot appends the contents of the queue to dst and returns the result.
 //
 // Use dst to avoid allocations when you know the capacity of the queue
@@ -101,3 +626,53 @@ func (q *MuQ[T]) Snapshot(dst []T) []T {
 	defer q.mu.RUnlock()
 	return q.q.Snapshot(dst)
 }
+
+// All returns an iterator over the items in the queue
+// and their positions, from front to back.
+//
+// The iterator operates on a snapshot of the queue
+// taken when iteration starts,
+// so the queue may be modified by the body of the loop
+// or by other goroutines while iterating.
+func (q *MuQ[T]) All() iter.Seq2[int, T] {
+	return func(yield func(int, T) bool) {
+		for i, x := range q.Snapshot(nil) {
+			if !yield(i, x) {
+				return
+			}
+		}
+	}
+}
+
+// Backward returns an iterator over the items in the queue
+// and their positions, from back to front.
+//
+// As with [MuQ.All], the iterator operates on a snapshot of the queue.
+func (q *MuQ[T]) Backward() iter.Seq2[int, T] {
+	return func(yield func(int, T) bool) {
+		items := q.Snapshot(nil)
+		for i := len(items) - 1; i >= 0; i-- {
+			if !yield(i, items[i]) {
+				return
+			}
+		}
+	}
+}
+
+// Drain returns an iterator that pops items from the front of the queue
+// until it's empty.
+// Each item is removed from the queue before it's yielded.
+//
+// Items are popped one at a time, each under its own lock,
+// so other goroutines may push or pop while draining.
+// If the loop stops early, the remaining items are left in the queue.
+func (q *MuQ[T]) Drain() iter.Seq[T] {
+	return func(yield func(T) bool) {
+		for {
+			x, ok := q.TryPop()
+			if !ok || !yield(x) {
+				return
+			}
+		}
+	}
+}
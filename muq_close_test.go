@@ -0,0 +1,214 @@
+package ring_test
+
+import (
+	"bytes"
+	"context"
+	"runtime"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.abhg.dev/container/ring"
+)
+
+func TestMuQ_Close(t *testing.T) {
+	t.Parallel()
+
+	t.Run("RejectsAdditions", func(t *testing.T) {
+		t.Parallel()
+
+		q := ring.MuQFromSlice([]int{1, 2})
+		assert.False(t, q.Closed())
+		q.Close()
+		assert.True(t, q.Closed())
+
+		assert.PanicsWithValue(t, ring.ErrClosed, func() { q.Push(3) })
+		assert.PanicsWithValue(t, ring.ErrClosed, func() { q.PushSlice(3) })
+		assert.PanicsWithValue(t, ring.ErrClosed, func() {
+			q.AppendQueue(ring.MuQFromSlice([]int{3}))
+		})
+		assert.ErrorIs(t, q.PushErr(3), ring.ErrClosed)
+		assert.False(t, q.TryPush(3))
+		assert.False(t, q.TryPushSlice(3))
+		assert.False(t, q.TryInsert(0, 3))
+		assert.ErrorIs(t, q.UnmarshalJSON([]byte(`[3]`)), ring.ErrClosed)
+
+		assert.Equal(t, []int{1, 2}, q.Snapshot(nil))
+	})
+
+	t.Run("Drains", func(t *testing.T) {
+		t.Parallel()
+
+		q := ring.MuQFromSlice([]int{1, 2, 3})
+		q.Close()
+
+		x, ok := q.TryPop()
+		assert.True(t, ok)
+		assert.Equal(t, 1, x)
+
+		x, err := q.PopWait(context.Background())
+		require.NoError(t, err)
+		assert.Equal(t, 2, x)
+
+		// Items may still be moved out of a closed queue.
+		var other ring.MuQ[int]
+		other.AppendQueue(q)
+		assert.Equal(t, []int{3}, other.Snapshot(nil))
+
+		_, ok = q.TryPop()
+		assert.False(t, ok)
+		_, err = q.PopWait(context.Background())
+		assert.ErrorIs(t, err, ring.ErrClosed)
+	})
+
+	t.Run("TryPopClosed", func(t *testing.T) {
+		t.Parallel()
+
+		q := ring.MuQFromSlice([]int{1})
+
+		x, ok, closed := q.TryPopClosed()
+		assert.Equal(t, 1, x)
+		assert.True(t, ok)
+		assert.False(t, closed)
+
+		_, ok, closed = q.TryPopClosed()
+		assert.False(t, ok)
+		assert.False(t, closed, "empty but still open")
+
+		q.Push(2)
+		q.Close()
+
+		// Not drained yet: the item pushed before Close is returned.
+		x, ok, closed = q.TryPopClosed()
+		assert.Equal(t, 2, x)
+		assert.True(t, ok)
+		assert.True(t, closed)
+
+		_, ok, closed = q.TryPopClosed()
+		assert.False(t, ok)
+		assert.True(t, closed, "drained")
+	})
+
+	t.Run("PushErr", func(t *testing.T) {
+		t.Parallel()
+
+		q := ring.NewMuQWithOptions[int](1, ring.WithMaxCapacity(1))
+		require.NoError(t, q.PushErr(1))
+
+		err := q.PushErr(2)
+		assert.ErrorIs(t, err, ring.ErrFull)
+		assert.NotErrorIs(t, err, ring.ErrClosed)
+
+		// Closed takes precedence over full.
+		q.Close()
+		err = q.PushErr(2)
+		assert.ErrorIs(t, err, ring.ErrClosed)
+		assert.NotErrorIs(t, err, ring.ErrFull)
+
+		assert.Equal(t, []int{1}, q.Snapshot(nil))
+	})
+
+	t.Run("DecodeFull", func(t *testing.T) {
+		t.Parallel()
+
+		var buf bytes.Buffer
+		require.NoError(t, ring.NewEncoder[int](&buf, intCodec{}).Encode(newWrappedQ()))
+
+		q := ring.NewMuQWithOptions[int](2, ring.WithMaxCapacity(2))
+		err := ring.NewDecoder[int](&buf, intCodec{}).DecodeMuQ(q)
+		assert.ErrorIs(t, err, ring.ErrFull)
+		assert.NotErrorIs(t, err, ring.ErrClosed)
+	})
+
+	t.Run("Idempotent", func(t *testing.T) {
+		t.Parallel()
+
+		var q ring.MuQ[int]
+		q.Close()
+		q.Close()
+		assert.True(t, q.Closed())
+	})
+
+	t.Run("CloneIsOpen", func(t *testing.T) {
+		t.Parallel()
+
+		q := ring.MuQFromSlice([]int{1})
+		q.Close()
+
+		c := q.Clone()
+		assert.False(t, c.Closed())
+		assert.True(t, c.TryPush(2))
+		assert.Equal(t, []int{1, 2}, c.Snapshot(nil))
+	})
+
+	t.Run("WakesWaiters", func(t *testing.T) {
+		t.Parallel()
+
+		const Waiters = 5
+
+		var q ring.MuQ[int]
+		errs := make(chan error, Waiters)
+		var started sync.WaitGroup
+		for range Waiters {
+			started.Add(1)
+			go func() {
+				started.Done()
+				_, err := q.PopWait(context.Background())
+				errs <- err
+			}()
+		}
+		started.Wait()
+
+		q.Close()
+		for range Waiters {
+			assert.ErrorIs(t, <-errs, ring.ErrClosed)
+		}
+	})
+
+	t.Run("Decode", func(t *testing.T) {
+		t.Parallel()
+
+		var buf bytes.Buffer
+		require.NoError(t, ring.NewEncoder[int](&buf, intCodec{}).Encode(newWrappedQ()))
+
+		var q ring.MuQ[int]
+		q.Close()
+		err := ring.NewDecoder[int](&buf, intCodec{}).DecodeMuQ(&q)
+		assert.ErrorIs(t, err, ring.ErrClosed)
+		assert.NotErrorIs(t, err, ring.ErrFull)
+	})
+}
+
+// Drains a queue with TryPopClosed while a producer pushes and closes it,
+// and verifies that no items are left behind.
+func TestMuQ_TryPopClosed_drain(t *testing.T) {
+	t.Parallel()
+
+	const Items = 1000
+
+	for range 100 {
+		var q ring.MuQ[int]
+		go func() {
+			for i := range Items {
+				q.Push(i)
+			}
+			q.Close()
+		}()
+
+		got := 0
+		for {
+			_, ok, closed := q.TryPopClosed()
+			if ok {
+				got++
+				continue
+			}
+			if closed {
+				break
+			}
+			runtime.Gosched()
+		}
+		require.Equal(t, Items, got)
+		require.True(t, q.Empty())
+	}
+}
@@ -1,8 +1,11 @@
 package ring_test
 
 import (
+	"context"
 	"sync"
+	"sync/atomic"
 	"testing"
+	"time"
 
 	"go.abhg.dev/container/ring"
 )
@@ -19,15 +22,80 @@ func TestMuQ_race(t *testing.T) {
 		Workers = 10
 	)
 
-	var q ring.MuQ[int]
+	// Methods that add items panic with ErrClosed
+	// once q has been closed.
+	ignoreClosed := func(fn func()) func() {
+		return func() {
+			defer func() {
+				if r := recover(); r != nil && r != ring.ErrClosed {
+					panic(r)
+				}
+			}()
+			fn()
+		}
+	}
+
+	var (
+		q, other ring.MuQ[int]
+		calls    atomic.Int64
+	)
 	funcs := []func(){
 		func() { q.Empty() },
 		func() { q.Len() },
+		func() { q.Cap() },
+		func() { q.Grow(10) },
 		q.Clear,
-		func() { q.Push(0) },
+		ignoreClosed(func() { q.Push(0) }),
+		func() { q.TryPush(0) },
+		func() { q.PushErr(0) },
 		func() { q.TryPop() },
+		func() { q.TryPopClosed() },
+		ignoreClosed(func() { q.PushSlice(1, 2, 3) }),
+		func() { q.PopN(nil, 2) },
+		func() { q.PeekN(nil, 2) },
+		func() { q.DiscardN(2) },
 		func() { q.TryPeek() },
+		func() { q.TryBack() },
+		func() { q.TryAt(1) },
+		func() { q.TrySet(1, 42) },
+		func() { q.TryInsert(1, 42) },
+		func() { q.TryRemove(1) },
+		func() { q.TryRemoveRange(1, 3) },
+		func() { q.DeleteFunc(func(x int) bool { return x == 2 }) },
+		func() { q.Rotate(3) },
+		q.Reverse,
+		func() { q.TrySwap(0, 1) },
 		func() { q.Snapshot(nil) },
+		func() { q.Clone() },
+		ignoreClosed(func() { q.AppendQueue(&other) }),
+		func() { other.AppendQueue(&q) },
+		func() { _, _ = q.MarshalJSON() },
+		func() { _ = q.UnmarshalJSON([]byte(`[1, 2, 3]`)) },
+		func() { _ = q.String() },
+		func() {
+			ctx, cancel := context.WithTimeout(context.Background(), time.Microsecond)
+			defer cancel()
+			_, _ = q.PopWait(ctx)
+		},
+		func() { q.Closed() },
+		func() {
+			select {
+			case <-q.Ready():
+			default:
+			}
+		},
+		func() {
+			ctx, cancel := context.WithTimeout(context.Background(), time.Microsecond)
+			defer cancel()
+			_, _ = ring.WaitAny(ctx, &q, &other)
+		},
+		func() {
+			// Close halfway through so that all other methods
+			// run both before and after the close.
+			if calls.Add(1) == Steps*Workers/2 {
+				q.Close()
+			}
+		},
 	}
 
 	var (
@@ -0,0 +1,93 @@
+package ring
+
+import "context"
+
+// PopWait removes and returns the item at the front of the queue,
+// blocking until an item is available or ctx is done.
+// If ctx is done before an item is available,
+// PopWait returns ctx.Err().
+//
+// If the queue is closed, PopWait keeps returning items until it's empty,
+// and then returns [ErrClosed] instead of blocking.
+//
+// Goroutines blocked in PopWait are woken in the order they started waiting,
+// one for each item added to the queue.
+// A woken goroutine competes with other callers of TryPop, PopWait, etc.
+// for the item, and goes back to waiting if it loses.
+//
+// This allows MuQ to be used as an unbounded work queue
+// shared by multiple producers and consumers:
+//
+//	for {
+//		job, err := jobs.PopWait(ctx)
+//		if errors.Is(err, ring.ErrClosed) {
+//			return nil // shutting down
+//		}
+//		if err != nil {
+//			return err
+//		}
+//		job.Run()
+//	}
+func (q *MuQ[T]) PopWait(ctx context.Context) (x T, err error) {
+	for {
+		q.mu.Lock()
+		if x, ok := q.q.TryPop(); ok {
+			q.mu.Unlock()
+			return x, nil
+		}
+		if q.closed {
+			q.mu.Unlock()
+			return x, ErrClosed
+		}
+		if err := ctx.Err(); err != nil {
+			q.mu.Unlock()
+			return x, err
+		}
+
+		wake := make(chan struct{})
+		q.waiters.Push(wake)
+		q.mu.Unlock()
+
+		select {
+		case <-wake:
+			// Try again.
+
+		case <-ctx.Done():
+			q.mu.Lock()
+			if q.waiters.DeleteFunc(func(w chan struct{}) bool { return w == wake }) == 0 {
+				// We were woken up concurrently with ctx being done.
+				// Pass the wake-up on so that the item isn't stranded.
+				q.notify(1)
+			}
+			q.mu.Unlock()
+			return x, ctx.Err()
+		}
+	}
+}
+
+// added is called after n items have been added to the queue.
+// It wakes up goroutines waiting for items.
+//
+// The caller must hold the write lock.
+func (q *MuQ[T]) added(n int) {
+	if n > 0 && q.q.Len() == n {
+		// The queue was empty before these items were added.
+		q.signalReady()
+	}
+	q.notify(n)
+}
+
+// notify wakes up to n goroutines blocked in PopWait
+// after n items have been added to the queue,
+// or after the queue has been closed.
+//
+// The caller must hold the write lock.
+func (q *MuQ[T]) notify(n int) {
+	for ; n > 0; n-- {
+		wake, ok := q.waiters.TryPop()
+		if !ok {
+			return
+		}
+		close(wake)
+	}
+}
@@ -0,0 +1,184 @@
+package ring_test
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.abhg.dev/container/ring"
+)
+
+func TestMuQ_PopWait(t *testing.T) {
+	t.Parallel()
+
+	t.Run("Available", func(t *testing.T) {
+		t.Parallel()
+
+		q := ring.MuQFromSlice([]int{1, 2})
+		x, err := q.PopWait(context.Background())
+		require.NoError(t, err)
+		assert.Equal(t, 1, x)
+		assert.Equal(t, 1, q.Len())
+	})
+
+	t.Run("AvailableAfterCancel", func(t *testing.T) {
+		t.Parallel()
+
+		// Items are returned even if the context is already done.
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+
+		q := ring.MuQFromSlice([]int{1})
+		x, err := q.PopWait(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, 1, x)
+
+		_, err = q.PopWait(ctx)
+		assert.ErrorIs(t, err, context.Canceled)
+	})
+
+	t.Run("Blocks", func(t *testing.T) {
+		t.Parallel()
+
+		var q ring.MuQ[int]
+		result := make(chan int)
+		go func() {
+			x, err := q.PopWait(context.Background())
+			assert.NoError(t, err)
+			result <- x
+		}()
+
+		q.Push(42)
+		assert.Equal(t, 42, <-result)
+	})
+
+	t.Run("Timeout", func(t *testing.T) {
+		t.Parallel()
+
+		var q ring.MuQ[int]
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+		defer cancel()
+
+		_, err := q.PopWait(ctx)
+		assert.ErrorIs(t, err, context.DeadlineExceeded)
+
+		// The queue is still usable.
+		q.Push(1)
+		x, err := q.PopWait(context.Background())
+		require.NoError(t, err)
+		assert.Equal(t, 1, x)
+	})
+
+	t.Run("WokenByAdditions", func(t *testing.T) {
+		t.Parallel()
+
+		add := map[string]func(t *testing.T, q *ring.MuQ[int]){
+			"TryPush":      func(_ *testing.T, q *ring.MuQ[int]) { q.TryPush(1) },
+			"PushSlice":    func(_ *testing.T, q *ring.MuQ[int]) { q.PushSlice(1) },
+			"TryPushSlice": func(_ *testing.T, q *ring.MuQ[int]) { q.TryPushSlice(1) },
+			"TryInsert":    func(_ *testing.T, q *ring.MuQ[int]) { q.TryInsert(0, 1) },
+			"AppendQueue": func(_ *testing.T, q *ring.MuQ[int]) {
+				q.AppendQueue(ring.MuQFromSlice([]int{1}))
+			},
+			"UnmarshalJSON": func(t *testing.T, q *ring.MuQ[int]) {
+				assert.NoError(t, q.UnmarshalJSON([]byte(`[1]`)))
+			},
+		}
+
+		for name, add := range add {
+			t.Run(name, func(t *testing.T) {
+				t.Parallel()
+
+				var q ring.MuQ[int]
+				result := make(chan int)
+				go func() {
+					x, err := q.PopWait(context.Background())
+					assert.NoError(t, err)
+					result <- x
+				}()
+
+				add(t, &q)
+				assert.Equal(t, 1, <-result)
+			})
+		}
+	})
+}
+
+// Runs multiple producers and consumers against a MuQ,
+// with some consumers giving up early,
+// and verifies that every item is consumed exactly once.
+func TestMuQ_PopWait_workQueue(t *testing.T) {
+	t.Parallel()
+
+	const (
+		Producers = 4
+		Consumers = 4
+		Items     = 1000 // per producer
+	)
+
+	var q ring.MuQ[int]
+
+	var producers sync.WaitGroup
+	for p := range Producers {
+		producers.Add(1)
+		go func() {
+			defer producers.Done()
+			for i := range Items {
+				q.Push(p*Items + i)
+			}
+		}()
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	var (
+		mu   sync.Mutex
+		seen = make(map[int]int)
+	)
+	var consumers sync.WaitGroup
+	for c := range Consumers {
+		consumers.Add(1)
+		go func() {
+			defer consumers.Done()
+			for {
+				popCtx, cancelPop := ctx, func() {}
+				if c%2 == 0 {
+					// Half of the consumers time out regularly
+					// to exercise wake-ups racing with cancellation.
+					popCtx, cancelPop = context.WithTimeout(ctx, time.Microsecond)
+				}
+
+				x, err := q.PopWait(popCtx)
+				cancelPop()
+				if err != nil {
+					if ctx.Err() != nil {
+						return
+					}
+					continue
+				}
+
+				mu.Lock()
+				seen[x]++
+				mu.Unlock()
+			}
+		}()
+	}
+
+	producers.Wait()
+	require.Eventually(t, func() bool {
+		mu.Lock()
+		defer mu.Unlock()
+		return len(seen) == Producers*Items
+	}, 10*time.Second, time.Millisecond)
+	cancel()
+	consumers.Wait()
+
+	for x, n := range seen {
+		assert.Equal(t, 1, n, "item %d", x)
+	}
+	assert.True(t, q.Empty())
+}
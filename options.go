@@ -0,0 +1,92 @@
+package ring
+
+import "fmt"
+
+// Option customizes a queue created with
+// [NewQWithOptions] or [NewMuQWithOptions].
+type Option interface {
+	apply(*options)
+}
+
+type options struct {
+	growth GrowthPolicy
+	shrink ShrinkPolicy
+	maxCap int
+}
+
+type optionFunc func(*options)
+
+func (f optionFunc) apply(o *options) { f(o) }
+
+// WithGrowthPolicy sets the policy the queue uses
+// to decide its new capacity when it's full.
+//
+// By default, queues double in size when they're full.
+// If the queue was created with [WithMaxCapacity],
+// it never grows beyond that capacity
+// regardless of the value returned by the policy.
+func WithGrowthPolicy(p GrowthPolicy) Option {
+	return optionFunc(func(o *options) {
+		o.growth = p
+	})
+}
+
+// WithShrinkPolicy sets the policy the queue uses
+// to release memory after items are removed from it.
+//
+// By default, queues never shrink on their own.
+func WithShrinkPolicy(p ShrinkPolicy) Option {
+	return optionFunc(func(o *options) {
+		o.shrink = p
+	})
+}
+
+// WithMaxCapacity limits the queue to at most n items.
+// The queue grows as needed up to that limit,
+// but once it holds n items,
+// TryPush reports failure and Push panics with [ErrFull].
+//
+// If n is zero, the queue is unbounded.
+// WithMaxCapacity panics if n is negative.
+func WithMaxCapacity(n int) Option {
+	if n < 0 {
+		panic(fmt.Sprintf("invalid maximum capacity: %d", n))
+	}
+	return optionFunc(func(o *options) {
+		o.maxCap = n
+	})
+}
+
+// NewQWithOptions returns a new queue with the given capacity,
+// customized with the given options.
+// If capacity is zero, the queue is initialized with a default capacity.
+//
+//	q := ring.NewQWithOptions[int](64,
+//		ring.WithShrinkPolicy(ring.ShrinkHalfAtQuarter),
+//	)
+func NewQWithOptions[T any](capacity int, opts ...Option) *Q[T] {
+	var q Q[T]
+	q.initOptions(capacity, opts)
+	return &q
+}
+
+// NewMuQWithOptions returns a new thread-safe queue with the given capacity,
+// customized with the given options.
+// If capacity is zero, the queue is initialized with a default capacity.
+func NewMuQWithOptions[T any](capacity int, opts ...Option) *MuQ[T] {
+	var m MuQ[T]
+	m.q.initOptions(capacity, opts)
+	return &m
+}
+
+func (q *Q[T]) initOptions(capacity int, opts []Option) {
+	var o options
+	for _, opt := range opts {
+		opt.apply(&o)
+	}
+
+	q.maxCap = o.maxCap
+	q.init(capacity)
+	q.growth = o.growth
+	q.shrink = o.shrink
+}
@@ -0,0 +1,103 @@
+package ring_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"go.abhg.dev/container/ring"
+)
+
+func TestWithMaxCapacity(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name     string
+		capacity int
+	}{
+		{"DefaultCapacity", 0},
+		{"SmallCapacity", 2},
+		{"LargeCapacity", 100},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			t.Run("Q", func(t *testing.T) {
+				t.Parallel()
+
+				q := ring.NewQWithOptions[int](tt.capacity, ring.WithMaxCapacity(5))
+				testMaxCapacity(t, q, 5)
+				assert.PanicsWithValue(t, ring.ErrFull, func() { q.Push(42) })
+			})
+
+			t.Run("MuQ", func(t *testing.T) {
+				t.Parallel()
+
+				q := ring.NewMuQWithOptions[int](tt.capacity, ring.WithMaxCapacity(5))
+				testMaxCapacity(t, q, 5)
+				assert.PanicsWithValue(t, ring.ErrFull, func() { q.Push(42) })
+			})
+		})
+	}
+}
+
+func testMaxCapacity(t *testing.T, q interface {
+	queue[int]
+	TryPush(int) bool
+}, maxCap int,
+) {
+	// Fill, drain partially, and fill again
+	// so that the contents wrap around.
+	for i := 0; i < maxCap; i++ {
+		assert.True(t, q.TryPush(i), "push %d", i)
+	}
+	assert.False(t, q.TryPush(maxCap), "push beyond max capacity")
+
+	requirePop(t, q)
+	requirePop(t, q)
+	assert.True(t, q.TryPush(maxCap), "push after pop")
+	assert.True(t, q.TryPush(maxCap+1), "push after pop")
+	assert.False(t, q.TryPush(maxCap+2), "push beyond max capacity")
+
+	assert.Equal(t, []int{2, 3, 4, 5, 6}, q.Snapshot(nil))
+}
+
+func TestWithMaxCapacity_unbounded(t *testing.T) {
+	t.Parallel()
+
+	q := ring.NewQWithOptions[int](1, ring.WithMaxCapacity(0))
+	for i := 0; i < 100; i++ {
+		assert.True(t, q.TryPush(i), "push %d", i)
+	}
+}
+
+func TestWithMaxCapacity_negative(t *testing.T) {
+	t.Parallel()
+
+	assert.PanicsWithValue(t, "invalid maximum capacity: -1", func() {
+		ring.WithMaxCapacity(-1)
+	})
+}
+
+func TestWithMaxCapacity_PushSlice(t *testing.T) {
+	t.Parallel()
+
+	q := ring.NewQWithOptions[int](0, ring.WithMaxCapacity(5))
+	q.PushSlice(1, 2, 3)
+	assert.False(t, q.TryPushSlice(4, 5, 6), "push beyond max capacity")
+	assert.Equal(t, []int{1, 2, 3}, q.Snapshot(nil), "no items must be added")
+	assert.PanicsWithValue(t, ring.ErrFull, func() { q.PushSlice(4, 5, 6) })
+
+	assert.True(t, q.TryPushSlice(4, 5), "push to max capacity")
+	assert.Equal(t, []int{1, 2, 3, 4, 5}, q.Snapshot(nil))
+}
+
+func TestWithMaxCapacity_Insert(t *testing.T) {
+	t.Parallel()
+
+	q := ring.NewQWithOptions[int](0, ring.WithMaxCapacity(3))
+	q.PushSlice(1, 2, 3)
+	assert.PanicsWithValue(t, ring.ErrFull, func() { q.Insert(1, 42) })
+	assert.Equal(t, []int{1, 2, 3}, q.Snapshot(nil))
+}
@@ -0,0 +1,94 @@
+package ring
+
+import (
+	"fmt"
+	"math/bits"
+)
+
+// GrowthPolicy decides how much a queue grows when it's full.
+//
+// It's called with the current capacity of a full queue,
+// and returns the new capacity of the queue.
+// If the returned capacity isn't larger than the current capacity,
+// the queue grows by one item.
+//
+// The default policy doubles the size of the queue.
+type GrowthPolicy func(capacity int) int
+
+var _ GrowthPolicy = GrowPowerOfTwo
+
+// GrowByFactor returns a [GrowthPolicy] that multiplies
+// the capacity of a queue by the given factor.
+// For example, GrowByFactor(1.5) grows queues by 50% each time.
+//
+// Smaller factors waste less memory on unused capacity,
+// but copy items more often as the queue grows.
+//
+// GrowByFactor panics if factor is not greater than 1.
+func GrowByFactor(factor float64) GrowthPolicy {
+	if !(factor > 1) {
+		panic(fmt.Sprintf("invalid growth factor: %v", factor))
+	}
+	return func(capacity int) int {
+		return int(float64(capacity) * factor)
+	}
+}
+
+// GrowByStep returns a [GrowthPolicy] that adds
+// a fixed number of items to the capacity of a queue.
+//
+// This bounds the memory wasted on unused capacity to step items,
+// but makes pushes O(n) instead of amortized O(1) for large queues.
+//
+// GrowByStep panics if step is not positive.
+func GrowByStep(step int) GrowthPolicy {
+	if step <= 0 {
+		panic(fmt.Sprintf("invalid growth step: %d", step))
+	}
+	return func(capacity int) int {
+		return capacity + step
+	}
+}
+
+// GrowPowerOfTwo is a [GrowthPolicy] that grows the capacity of a queue
+// to the smallest power of two greater than its current capacity.
+func GrowPowerOfTwo(capacity int) int {
+	if capacity <= 0 {
+		return 1
+	}
+	return 1 << bits.Len(uint(capacity))
+}
+
+// ShrinkPolicy decides when a queue should release unused memory.
+//
+// It's called after items are removed from a queue
+// with the number of items left in it and its current capacity.
+// It returns the capacity the queue should shrink to.
+// Returning capacity unchanged leaves the queue as-is.
+//
+// If the policy asks the queue to shrink,
+// it's consulted again with the new capacity
+// until it stops shrinking.
+// This lets a single call to Clear release all memory it needs to.
+//
+// The queue never shrinks below its length
+// or below the capacity it was created with,
+// regardless of the value returned by the policy.
+//
+// Shrinking is an O(n) operation that allocates a new buffer,
+// so policies should leave enough room between the points
+// where the queue grows and shrinks
+// to avoid resizing repeatedly when the length hovers around a boundary.
+type ShrinkPolicy func(length, capacity int) int
+
+var _ ShrinkPolicy = ShrinkHalfAtQuarter
+
+// ShrinkHalfAtQuarter is a [ShrinkPolicy] that halves
+// the capacity of a queue once its length drops
+// below a quarter of its capacity.
+func ShrinkHalfAtQuarter(length, capacity int) int {
+	if length < capacity/4 {
+		return capacity / 2
+	}
+	return capacity
+}
@@ -0,0 +1,125 @@
+package ring_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"go.abhg.dev/container/ring"
+)
+
+func TestGrowthPolicy(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name   string
+		policy ring.GrowthPolicy
+		give   []int
+		want   []int
+	}{
+		{
+			name:   "GrowByFactor",
+			policy: ring.GrowByFactor(1.5),
+			give:   []int{1, 2, 10, 100},
+			want:   []int{1, 3, 15, 150},
+		},
+		{
+			name:   "GrowByStep",
+			policy: ring.GrowByStep(8),
+			give:   []int{0, 1, 8, 100},
+			want:   []int{8, 9, 16, 108},
+		},
+		{
+			name:   "GrowPowerOfTwo",
+			policy: ring.GrowPowerOfTwo,
+			give:   []int{0, 1, 2, 3, 7, 8, 100},
+			want:   []int{1, 2, 4, 4, 8, 16, 128},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			for i, give := range tt.give {
+				assert.Equal(t, tt.want[i], tt.policy(give), "policy(%d)", give)
+			}
+		})
+	}
+}
+
+func TestGrowthPolicy_invalid(t *testing.T) {
+	t.Parallel()
+
+	assert.PanicsWithValue(t, "invalid growth factor: 1", func() {
+		ring.GrowByFactor(1)
+	}, "factor")
+	assert.PanicsWithValue(t, "invalid growth step: 0", func() {
+		ring.GrowByStep(0)
+	}, "step")
+}
+
+func TestWithGrowthPolicy(t *testing.T) {
+	t.Parallel()
+
+	policies := []struct {
+		name   string
+		policy ring.GrowthPolicy
+	}{
+		{"GrowByFactor", ring.GrowByFactor(1.5)},
+		{"GrowByStep", ring.GrowByStep(64)},
+		{"GrowPowerOfTwo", ring.GrowPowerOfTwo},
+	}
+
+	for _, p := range policies {
+		t.Run(p.name, func(t *testing.T) {
+			t.Parallel()
+
+			testQueueSuite(t, func(capacity int) queue[int] {
+				return ring.NewQWithOptions[int](capacity, ring.WithGrowthPolicy(p.policy))
+			})
+		})
+	}
+}
+
+func TestWithGrowthPolicy_capacity(t *testing.T) {
+	t.Parallel()
+
+	q := ring.NewQWithOptions[int](4, ring.WithGrowthPolicy(ring.GrowByStep(10)))
+
+	var caps []int
+	for i := 0; i < 40; i++ {
+		q.Push(i)
+		if n := len(caps); n == 0 || caps[n-1] != q.Cap() {
+			caps = append(caps, q.Cap())
+		}
+	}
+	assert.Equal(t, []int{4, 14, 24, 34, 44}, caps)
+}
+
+// Policies that don't grow the queue are treated as growing it by one.
+func TestWithGrowthPolicy_noGrowth(t *testing.T) {
+	t.Parallel()
+
+	q := ring.NewQWithOptions[int](2, ring.WithGrowthPolicy(func(capacity int) int {
+		return capacity
+	}))
+	for i := 0; i < 5; i++ {
+		q.Push(i)
+	}
+	assert.Equal(t, []int{0, 1, 2, 3, 4}, q.Snapshot(nil))
+	assert.Equal(t, 5, q.Cap(), "capacity")
+}
+
+func TestWithGrowthPolicy_maxCapacity(t *testing.T) {
+	t.Parallel()
+
+	q := ring.NewMuQWithOptions[int](4,
+		ring.WithGrowthPolicy(ring.GrowByStep(100)),
+		ring.WithMaxCapacity(10),
+	)
+	for i := 0; i < 10; i++ {
+		q.Push(i)
+	}
+	assert.Equal(t, 10, q.Cap(), "capacity")
+	assert.False(t, q.TryPush(10), "push beyond max capacity")
+}
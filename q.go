@@ -1,5 +1,10 @@
 package ring
 
+import (
+	"fmt"
+	"iter"
+)
+
 const _defaultCapacity = 16
 
 // Q is a FIFO queue backed by a ring buffer.
@@ -20,6 +25,31 @@ type Q[T any] struct {
 
 	// tail is the index of the next empty slot in buff.
 	tail int // inv: 0 <= tail < len(buff)
+
+	// minCap is the capacity the queue was created with.
+	// Automatic shrinking never reduces capacity below this.
+	minCap int
+
+	// shrink decides when the queue releases unused memory.
+	// If nil, the queue never shrinks on its own.
+	shrink ShrinkPolicy
+
+	// maxCap is the maximum number of items the queue may hold.
+	// If zero, the queue is unbounded.
+	maxCap int
+
+	// growth decides how much the queue grows when it's full.
+	// If nil, the queue doubles in size.
+	growth GrowthPolicy
+
+	// mods counts operations that add or remove items
+	// or change the capacity of the queue.
+	// Iterators use it to detect modifications made while iterating.
+	mods uint64
+
+	// viewed is set in debug builds
+	// if slices aliasing buff were handed out by Segments or MakeContiguous.
+	viewed bool
 }
 
 // NewQ returns a new queue with the given capacity.
@@ -37,12 +67,16 @@ func (q *Q[T]) init(capacity int) {
 	if capacity == 0 {
 		capacity = _defaultCapacity
 	}
+	if q.maxCap > 0 {
+		capacity = min(capacity, q.maxCap)
+	}
 	// Allocate requested capacity plus one slot
 	// so that filling the queue to exactly the requested capacity
 	// doesn't require resizing.
 	q.buff = make([]T, capacity+1)
 	q.head = 0
 	q.tail = 0
+	q.minCap = capacity
 }
 
 // Empty returns true if the queue is empty.
@@ -62,23 +96,97 @@ func (q *Q[T]) Len() int {
 	return len(q.buff) - q.head + q.tail
 }
 
-// Clear removes all items from the queue.
-// It does not adjust its internal capacity.
+// Cap returns the number of items the queue can hold
+// without growing.
 //
 // This is an O(1) operation and does not allocate.
+func (q *Q[T]) Cap() int {
+	if len(q.buff) == 0 {
+		return 0
+	}
+	// One slot is always left empty
+	// to tell a full buffer apart from an empty one.
+	return len(q.buff) - 1
+}
+
+// Grow increases the capacity of the queue, if necessary,
+// to guarantee space for n more items.
+// After Grow(n), at least n items can be pushed to the queue
+// without another allocation.
+// It panics if n is negative.
+//
+// If the queue was created with [WithMaxCapacity],
+// Grow never grows the queue beyond that capacity.
+// If the queue has a [ShrinkPolicy],
+// removing items may release the reserved space.
+//
+// Use this before a burst of pushes of a known size
+// to avoid growing the queue several times.
+func (q *Q[T]) Grow(n int) {
+	checkCount(n)
+	if q.maxCap > 0 {
+		n = min(n, q.maxCap-q.Len())
+	}
+	if q.Len()+n <= q.Cap() {
+		return
+	}
+
+	q.mutate()
+	q.reserve(n)
+}
+
+// Clear removes all items from the queue.
+// It does not adjust its internal capacity
+// unless the queue has a [ShrinkPolicy].
+//
+// This is an O(n) operation as it zeroes out the removed items
+// so that they may be garbage collected.
+// It does not allocate
+// unless the queue has a [ShrinkPolicy] that decides to shrink it.
 func (q *Q[T]) Clear() {
+	q.mutate()
+	if q.head <= q.tail {
+		clear(q.buff[q.head:q.tail])
+	} else {
+		clear(q.buff[q.head:])
+		clear(q.buff[:q.tail])
+	}
 	q.head = 0
 	q.tail = 0
+	q.maybeShrink()
 }
 
 // Push adds x to the back of the queue.
+// If the queue was created with [WithMaxCapacity]
+// and it's already full, Push panics with [ErrFull].
 //
 // This operation is O(n) in the worst case if the queue needs to grow.
 // However, for target use cases, it's amortized O(1).
 // See package documentation for details.
 func (q *Q[T]) Push(x T) {
+	if !q.TryPush(x) {
+		panic(ErrFull)
+	}
+}
+
+// TryPush adds x to the back of the queue.
+// It returns false if the queue was created with [WithMaxCapacity]
+// and it's already full.
+// Otherwise, it returns true.
+//
+// TryPush always succeeds for queues without a maximum capacity.
+//
+// This operation is O(n) in the worst case if the queue needs to grow.
+// However, for target use cases, it's amortized O(1).
+// See package documentation for details.
+func (q *Q[T]) TryPush(x T) bool {
+	if q.maxCap > 0 && q.Len() >= q.maxCap {
+		return false
+	}
+
+	q.mutate()
 	if len(q.buff) == 0 {
-		q.buff = make([]T, _defaultCapacity)
+		q.buff = make([]T, q.grownSize(0))
 	}
 
 	q.buff[q.tail] = x
@@ -95,19 +203,74 @@ func (q *Q[T]) Push(x T) {
 	// copying buff[head:] and buff[:tail] to the new buffer.
 	if q.head == q.tail {
 		// The queue is full. Make room.
-		buff := make([]T, 2*len(q.buff))
-		n := copy(buff, q.buff[q.head:])
-		n += copy(buff[n:], q.buff[:q.tail])
-		q.head = 0
-		q.tail = n
-		q.buff = buff
+		q.grow()
+	}
+	return true
+}
+
+// pushFront adds x to the front of the queue.
+//
+// This mirrors Push, moving the head backwards instead of the tail forwards.
+func (q *Q[T]) pushFront(x T) {
+	q.mutate()
+	if len(q.buff) == 0 {
+		q.buff = make([]T, q.grownSize(0))
+	}
+
+	if q.head == 0 {
+		// Wrap around.
+		q.head = len(q.buff)
+	}
+	q.head--
+	q.buff[q.head] = x
+
+	// Same as Push: if the head has caught up with the tail,
+	// the queue is full.
+	if q.head == q.tail {
+		q.grow()
+	}
+}
+
+// grow increases the size of a full buffer.
+// It must be called only if the buffer is full: head == tail after a push.
+func (q *Q[T]) grow() {
+	// The contents of a full buffer are buff[head:] + buff[:tail].
+	buff := make([]T, q.grownSize(len(q.buff)))
+	n := copy(buff, q.buff[q.head:])
+	n += copy(buff[n:], q.buff[:q.tail])
+	q.head = 0
+	q.tail = n
+	q.buff = buff
+}
+
+// grownSize returns the size of the buffer
+// that should replace a full buffer of the given size.
+func (q *Q[T]) grownSize(size int) int {
+	var newSize int
+	switch {
+	case size == 0:
+		newSize = _defaultCapacity
+	case q.growth != nil:
+		// The policy works in terms of capacity, not buffer size.
+		// Guard against policies that don't grow the queue.
+		newSize = max(q.growth(size-1), size) + 1
+	default:
+		newSize = 2 * size
+	}
+
+	if q.maxCap > 0 {
+		// There's no need to grow beyond the maximum capacity
+		// plus the slot that's always left empty.
+		newSize = min(newSize, q.maxCap+1)
 	}
+	return newSize
 }
 
 // Pop removes and returns the item at the front of the queue.
 // It panics if the queue is empty.
 //
-// This is an O(1) operation and does not allocate.
+// This is an O(1) operation and does not allocate
+// unless the queue has a [ShrinkPolicy] that decides to shrink it.
 func (q *Q[T]) Pop() T {
 	x, ok := q.TryPop()
 	if !ok {
@@ -120,13 +283,19 @@ func (q *Q[T]) Pop() T {
 // It returns false if the queue is empty.
 // Otherwise, it returns true and the item.
 //
-// This is an O(1) operation and does not allocate.
+// This is an O(1) operation and does not allocate
+// unless the queue has a [ShrinkPolicy] that decides to shrink it.
 func (q *Q[T]) TryPop() (x T, ok bool) {
 	if q.head == q.tail {
 		return x, false
 	}
 
+	q.mutate()
 	x = q.buff[q.head]
+	// Drop the reference so that x may be garbage collected
+	// once the caller is done with it.
+	var zero T
+	q.buff[q.head] = zero
 	q.head++
 	if q.head == len(q.buff) {
 		// Wrap around.
@@ -135,6 +304,7 @@ func (q *Q[T]) TryPop() (x T, ok bool) {
 		// the next Pop will catch it when head == tail.
 		q.head = 0
 	}
+	q.maybeShrink()
 	return x, true
 }
 
@@ -162,6 +332,166 @@ func (q *Q[T]) TryPeek() (x T, ok bool) {
 	return q.buff[q.head], true
 }
 
+// tryPopBack removes and returns the item at the back of the queue.
+// It returns false if the queue is empty.
+func (q *Q[T]) tryPopBack() (x T, ok bool) {
+	if q.head == q.tail {
+		return x, false
+	}
+
+	q.mutate()
+	if q.tail == 0 {
+		// Wrap around.
+		q.tail = len(q.buff)
+	}
+	q.tail--
+	x = q.buff[q.tail]
+	var zero T
+	q.buff[q.tail] = zero
+	q.maybeShrink()
+	return x, true
+}
+
+// ShrinkToFit reduces the capacity of the queue
+// to the number of items in it, releasing unused memory.
+// If the queue is empty, all memory held by it is released.
+//
+// This ignores the queue's [ShrinkPolicy] and its initial capacity.
+//
+// This is an O(n) operation that allocates
+// if the queue has unused capacity.
+func (q *Q[T]) ShrinkToFit() {
+	n := q.Len()
+	switch {
+	case n == 0 && q.buff != nil:
+		q.mutate()
+		q.buff = nil
+		q.head = 0
+		q.tail = 0
+	case n > 0 && n < q.Cap():
+		q.mutate()
+		q.resize(n + 1)
+	}
+}
+
+// maybeShrink consults the shrink policy after items are removed,
+// and shrinks the buffer if the policy asks for it.
+func (q *Q[T]) maybeShrink() {
+	if q.shrink == nil {
+		return
+	}
+
+	// Apply the policy until it stops shrinking
+	// so that removing many items at once (e.g. Clear)
+	// doesn't take several calls to reach the target.
+	n, floor := q.Len(), max(q.minCap, q.Len())
+	capacity := q.Cap()
+	for capacity > floor {
+		next := max(q.shrink(n, capacity), floor)
+		if next >= capacity {
+			break
+		}
+		capacity = next
+	}
+
+	if capacity < q.Cap() {
+		q.resize(capacity + 1)
+	}
+}
+
+// resize moves the contents of the queue into a new buffer of the given size.
+// The first item is placed at index 0.
+//
+// size must be greater than the number of items in the queue.
+func (q *Q[T]) resize(size int) {
+	buff := q.Snapshot(make([]T, 0, size))
+	q.head = 0
+	q.tail = len(buff)
+	q.buff = buff[:size]
+}
+
+// Back returns the item at the back of the queue without removing it.
+// This is the most recently pushed item.
+// It panics if the queue is empty.
+//
+// This is an O(1) operation and does not allocate.
+func (q *Q[T]) Back() T {
+	x, ok := q.TryBack()
+	if !ok {
+		panic("empty queue")
+	}
+	return x
+}
+
+// TryBack returns the item at the back of the queue.
+// This is the most recently pushed item.
+// It returns false if the queue is empty.
+// Otherwise, it returns true and the item.
+//
+// This is an O(1) operation and does not allocate.
+func (q *Q[T]) TryBack() (x T, ok bool) {
+	if q.head == q.tail {
+		return x, false
+	}
+
+	if q.tail == 0 {
+		return q.buff[len(q.buff)-1], true
+	}
+	return q.buff[q.tail-1], true
+}
+
+// At returns the item at position i in the queue
+// without removing it.
+// Position 0 is the front of the queue,
+// and position Len()-1 is the back.
+// It panics if i is out of range.
+//
+// This is an O(1) operation and does not allocate.
+func (q *Q[T]) At(i int) T {
+	q.checkIndex(i)
+	return q.buff[q.index(i)]
+}
+
+// TryAt returns the item at position i in the queue.
+// It returns false if i is out of range.
+// Otherwise, it returns true and the item.
+//
+// This is an O(1) operation and does not allocate.
+func (q *Q[T]) TryAt(i int) (x T, ok bool) {
+	if i < 0 || i >= q.Len() {
+		return x, false
+	}
+	return q.buff[q.index(i)], true
+}
+
+// Set replaces the item at position i in the queue with x.
+// It panics if i is out of range.
+//
+// This is an O(1) operation and does not allocate.
+func (q *Q[T]) Set(i int, x T) {
+	q.checkIndex(i)
+	q.buff[q.index(i)] = x
+}
+
+// index returns the position in buff
+// of the item at position i in the queue.
+//
+// i must be in the range [0, Len()).
+func (q *Q[T]) index(i int) int {
+	idx := q.head + i
+	if idx >= len(q.buff) {
+		idx -= len(q.buff)
+	}
+	return idx
+}
+
+// checkIndex panics if i is not a valid position in the queue.
+func (q *Q[T]) checkIndex(i int) {
+	if n := q.Len(); i < 0 || i >= n {
+		panic(fmt.Sprintf("index out of range [%d] with length %d", i, n))
+	}
+}
+
 // Snapshot appends the contents of the queue to dst and returns the result.
 // Use dst to avoid allocations when you know the capacity of the queue
 //
@@ -181,3 +511,79 @@ func (q *Q[T]) Snapshot(dst []T) []T {
 	dst = append(dst, q.buff[q.head:]...)
 	return append(dst, q.buff[:q.tail]...)
 }
+
+// All returns an iterator over the items in the queue
+// and their positions, from front to back.
+// The queue is not modified.
+//
+//	for i, x := range q.All() {
+//		fmt.Println(i, x)
+//	}
+//
+// The queue must not be modified while iterating over it.
+// All panics if it detects that the queue was modified
+// by the body of the loop.
+// Use [Q.Drain] to consume items while adding new ones.
+func (q *Q[T]) All() iter.Seq2[int, T] {
+	return func(yield func(int, T) bool) {
+		mods := q.mods
+		n := q.Len()
+		for i := 0; i < n; i++ {
+			if !yield(i, q.buff[q.index(i)]) {
+				return
+			}
+			q.checkUnmodified(mods)
+		}
+	}
+}
+
+// Backward returns an iterator over the items in the queue
+// and their positions, from back to front.
+// The queue is not modified.
+//
+// As with [Q.All], the queue must not be modified while iterating over it.
+func (q *Q[T]) Backward() iter.Seq2[int, T] {
+	return func(yield func(int, T) bool) {
+		mods := q.mods
+		for i := q.Len() - 1; i >= 0; i-- {
+			if !yield(i, q.buff[q.index(i)]) {
+				return
+			}
+			q.checkUnmodified(mods)
+		}
+	}
+}
+
+// Drain returns an iterator that pops items from the front of the queue
+// until it's empty.
+// Each item is removed from the queue before it's yielded.
+//
+// Unlike [Q.All], the queue may be modified while draining it.
+// Items pushed by the body of the loop will be yielded in turn.
+//
+//	for n := range pending.Drain() {
+//		for _, child := range n.Children {
+//			pending.Push(child)
+//		}
+//	}
+//
+// If the loop stops early, the remaining items are left in the queue.
+func (q *Q[T]) Drain() iter.Seq[T] {
+	return func(yield func(T) bool) {
+		for {
+			x, ok := q.TryPop()
+			if !ok || !yield(x) {
+				return
+			}
+		}
+	}
+}
+
+// checkUnmodified panics if items were added or removed,
+// or the capacity of the queue changed,
+// since an iterator captured mods.
+func (q *Q[T]) checkUnmodified(mods uint64) {
+	if q.mods != mods {
+		panic("queue modified during iteration")
+	}
+}
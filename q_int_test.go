@@ -1,9 +1,13 @@
 package ring
 
 import (
+	"context"
 	"testing"
+	"time"
+	"unsafe"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 // Verifies that a queue filled exactly to capacity does not resize.
@@ -17,3 +21,315 @@ func TestQ_fillNoResize(t *testing.T) {
 	q.Push(3)
 	assert.Equal(t, initCap, cap(q.buff), "capacity")
 }
+
+// Verifies that a queue filled up to the space reserved by Grow
+// does not resize.
+func TestQ_growNoResize(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name string
+		give func() *Q[int]
+	}{
+		{"Zero", func() *Q[int] { return new(Q[int]) }},
+		{"Small", func() *Q[int] { return NewQ[int](3) }},
+		{
+			"Wrapped",
+			func() *Q[int] {
+				q := NewQ[int](3)
+				q.PushSlice(1, 2, 3)
+				q.Pop()
+				q.Pop()
+				return q
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			q := tt.give()
+			q.Grow(100)
+			assert.GreaterOrEqual(t, q.Cap(), q.Len()+100, "capacity")
+
+			initCap := cap(q.buff)
+			for i := 0; i < 100; i++ {
+				q.Push(i)
+			}
+			assert.Equal(t, initCap, cap(q.buff), "capacity")
+		})
+	}
+}
+
+func TestQ_growMaxCapacity(t *testing.T) {
+	t.Parallel()
+
+	q := NewQWithOptions[int](2, WithMaxCapacity(10))
+	q.Grow(100)
+	assert.Equal(t, 10, q.Cap(), "capacity")
+}
+
+func TestQ_shrinkPolicy(t *testing.T) {
+	t.Parallel()
+
+	q := NewQWithOptions[int](8, WithShrinkPolicy(ShrinkHalfAtQuarter))
+	for i := 0; i < 1000; i++ {
+		q.Push(i)
+	}
+	grown := q.Cap()
+	require.Greater(t, grown, 1000, "capacity after burst")
+
+	for i := 0; i < 1000; i++ {
+		assert.Equal(t, i, q.Pop(), "pop")
+		assert.GreaterOrEqual(t, q.Cap(), q.Len(), "capacity must fit items")
+	}
+	assert.Equal(t, 8, q.Cap(), "capacity must shrink down to initial capacity")
+}
+
+func TestQ_shrinkPolicyClear(t *testing.T) {
+	t.Parallel()
+
+	q := NewQWithOptions[int](4, WithShrinkPolicy(ShrinkHalfAtQuarter))
+	for i := 0; i < 100; i++ {
+		q.Push(i)
+	}
+	q.Clear()
+	assert.Equal(t, 4, q.Cap(), "capacity")
+}
+
+func TestQ_noShrinkPolicy(t *testing.T) {
+	t.Parallel()
+
+	q := NewQ[int](4)
+	for i := 0; i < 100; i++ {
+		q.Push(i)
+	}
+	grown := q.Cap()
+	for i := 0; i < 100; i++ {
+		q.Pop()
+	}
+	assert.Equal(t, grown, q.Cap(), "capacity")
+}
+
+func TestQ_ShrinkToFit(t *testing.T) {
+	t.Parallel()
+
+	q := NewQ[int](100)
+	for i := 0; i < 10; i++ {
+		q.Push(i)
+	}
+	q.Pop()
+
+	q.ShrinkToFit()
+	assert.Equal(t, 9, q.Cap(), "capacity")
+	assert.Equal(t, []int{1, 2, 3, 4, 5, 6, 7, 8, 9}, q.Snapshot(nil), "items")
+
+	// Already fits: no reallocation.
+	buff := q.buff
+	q.ShrinkToFit()
+	assert.Same(t, &buff[0], &q.buff[0], "buffer must not change")
+
+	q.Clear()
+	q.ShrinkToFit()
+	assert.Nil(t, q.buff, "buffer must be released")
+
+	q.Push(42)
+	assert.Equal(t, 42, q.Pop(), "queue must be usable after release")
+}
+
+// Verifies that removed items don't linger in the buffer.
+func TestQ_zeroRemoved(t *testing.T) {
+	t.Parallel()
+
+	newQ := func() *Q[*int] {
+		q := NewQ[*int](4)
+		// Wrap the contents around the end of the buffer.
+		q.Push(nil)
+		q.Push(nil)
+		q.Pop()
+		q.Pop()
+		for i := 0; i < 4; i++ {
+			q.Push(new(int))
+		}
+		return q
+	}
+
+	assertZero := func(t *testing.T, q *Q[*int]) {
+		live := make(map[int]struct{})
+		for i := 0; i < q.Len(); i++ {
+			live[q.index(i)] = struct{}{}
+		}
+		for i, x := range q.buff {
+			if _, ok := live[i]; !ok {
+				assert.Nil(t, x, "buff[%d] must be zero", i)
+			}
+		}
+	}
+
+	t.Run("Pop", func(t *testing.T) {
+		q := newQ()
+		q.Pop()
+		q.Pop()
+		q.Pop()
+		assertZero(t, q)
+	})
+
+	t.Run("popBack", func(t *testing.T) {
+		q := newQ()
+		q.tryPopBack()
+		q.tryPopBack()
+		q.tryPopBack()
+		assertZero(t, q)
+	})
+
+	t.Run("Clear", func(t *testing.T) {
+		q := newQ()
+		q.Clear()
+		assertZero(t, q)
+	})
+
+	t.Run("RetainFunc", func(t *testing.T) {
+		q := newQ()
+		var n int
+		q.RetainFunc(func(*int) bool {
+			n++
+			return n%2 == 0
+		})
+		assert.Equal(t, 2, q.Len(), "len")
+		assertZero(t, q)
+	})
+}
+
+// Verifies that a queue with a maximum capacity
+// doesn't allocate more than it needs.
+func TestQ_maxCapacityBuffer(t *testing.T) {
+	t.Parallel()
+
+	q := NewQWithOptions[int](2, WithMaxCapacity(5))
+	for q.TryPush(0) {
+	}
+	assert.Equal(t, 5, q.Len(), "len")
+	assert.Equal(t, 5, q.Cap(), "capacity")
+}
+
+// Verifies that PushSlice grows the queue at most once.
+func TestQ_PushSliceGrowsOnce(t *testing.T) {
+	// Not parallel: AllocsPerRun doesn't support it.
+
+	// AllocsPerRun runs the function once to warm up,
+	// and then the requested number of times.
+	queues := make([]*Q[int], 2)
+	for i := range queues {
+		q := NewQ[int](4)
+		q.PushSlice(1, 2, 3)
+		q.Pop()
+		q.Pop()
+		queues[i] = q
+	}
+
+	xs := make([]int, 100)
+	var run int
+	allocs := testing.AllocsPerRun(1, func() {
+		queues[run].PushSlice(xs...)
+		run++
+	})
+	assert.Equal(t, 1.0, allocs, "allocations")
+
+	for _, q := range queues {
+		assert.Equal(t, 101, q.Len(), "len")
+	}
+}
+
+func TestQ_PushSliceWraparound(t *testing.T) {
+	t.Parallel()
+
+	q := NewQ[int](5)
+	q.PushSlice(-1, -1, -1, -1)
+	q.DiscardN(3)
+
+	// Contents are [-1], tail is at 4, and there are 4 free slots:
+	// buff[4], buff[5], buff[0], buff[1].
+	initCap := cap(q.buff)
+	q.PushSlice(1, 2, 3, 4)
+	assert.Equal(t, initCap, cap(q.buff), "capacity")
+	assert.Equal(t, []int{-1, 1, 2, 3, 4}, q.Snapshot(nil))
+	assert.Equal(t, []int{-1, 1, 2, 3, 4}, q.PopN(nil, 10))
+	assert.True(t, q.Empty(), "empty")
+}
+
+// Verifies that each item added to a MuQ wakes exactly one PopWait caller.
+func TestMuQ_PopWaitWakesOne(t *testing.T) {
+	t.Parallel()
+
+	var q MuQ[int]
+	numWaiters := func() int {
+		q.mu.RLock()
+		defer q.mu.RUnlock()
+		return q.waiters.Len()
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	results := make(chan int)
+	for range 3 {
+		go func() {
+			if x, err := q.PopWait(ctx); err == nil {
+				results <- x
+			}
+		}()
+	}
+	require.Eventually(t, func() bool { return numWaiters() == 3 },
+		time.Second, time.Millisecond)
+
+	q.Push(1)
+	assert.Equal(t, 1, <-results)
+	assert.Equal(t, 2, numWaiters())
+
+	q.PushSlice(2, 3)
+	assert.ElementsMatch(t, []int{2, 3}, []int{<-results, <-results})
+	assert.Zero(t, numWaiters())
+}
+
+// Verifies that PopWait callers whose context is done
+// stop waiting for items.
+func TestMuQ_PopWaitCancelRemovesWaiter(t *testing.T) {
+	t.Parallel()
+
+	var q MuQ[int]
+	ctx, cancel := context.WithCancel(context.Background())
+
+	done := make(chan error)
+	go func() {
+		_, err := q.PopWait(ctx)
+		done <- err
+	}()
+	require.Eventually(t, func() bool { return !waitersEmpty(&q) },
+		time.Second, time.Millisecond)
+
+	cancel()
+	assert.ErrorIs(t, <-done, context.Canceled)
+	assert.True(t, waitersEmpty(&q))
+}
+
+func waitersEmpty[T any](q *MuQ[T]) bool {
+	q.mu.RLock()
+	defer q.mu.RUnlock()
+	return q.waiters.Empty()
+}
+
+// Verifies that the fields written by the producer and the consumer
+// of an SPSC are on different cache lines.
+func TestSPSC_padding(t *testing.T) {
+	t.Parallel()
+
+	var s SPSC[int]
+	head := unsafe.Offsetof(s.head)
+	tail := unsafe.Offsetof(s.tail)
+	buff := unsafe.Offsetof(s.buff)
+
+	assert.GreaterOrEqual(t, head, uintptr(_cacheLineSize), "head from start")
+	assert.GreaterOrEqual(t, tail-head, uintptr(_cacheLineSize), "tail from head")
+	assert.GreaterOrEqual(t, buff-tail, uintptr(_cacheLineSize), "buff from tail")
+}
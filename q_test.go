@@ -1,9 +1,11 @@
 package ring_test
 
 import (
+	"slices"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 	"go.abhg.dev/container/ring"
 )
 
@@ -15,6 +17,8 @@ func TestQ_empty(t *testing.T) {
 	assert.Zero(t, q.Len(), "len")
 	assert.Panics(t, func() { q.Peek() }, "peek")
 	assert.Panics(t, func() { q.Pop() }, "pop")
+	assert.Panics(t, func() { q.Back() }, "back")
+	assert.Panics(t, func() { q.At(0) }, "at")
 	assert.Empty(t, q.Snapshot(nil), "snapshot")
 }
 
@@ -27,3 +31,135 @@ func TestQ_PeekPop(t *testing.T) {
 	assert.Equal(t, 42, q.Pop(), "pop")
 	assert.True(t, q.Empty(), "empty")
 }
+
+func TestQ_AtSet(t *testing.T) {
+	t.Parallel()
+
+	// Wrap the contents around the end of the buffer.
+	q := ring.NewQ[int](4)
+	q.Push(-1)
+	q.Push(-1)
+	q.Pop()
+	q.Pop()
+	for i := 0; i < 4; i++ {
+		q.Push(i)
+	}
+
+	for i := 0; i < 4; i++ {
+		assert.Equal(t, i, q.At(i), "at(%d)", i)
+		q.Set(i, i*10)
+	}
+	assert.Equal(t, []int{0, 10, 20, 30}, q.Snapshot(nil))
+	assert.Equal(t, 30, q.Back(), "back")
+
+	assert.PanicsWithValue(t, "index out of range [4] with length 4", func() {
+		q.At(4)
+	}, "at")
+	assert.PanicsWithValue(t, "index out of range [-1] with length 4", func() {
+		q.Set(-1, 42)
+	}, "set")
+
+	_, ok := q.TryAt(4)
+	assert.False(t, ok, "try at")
+}
+
+func TestQ_negativeCount(t *testing.T) {
+	t.Parallel()
+
+	var q ring.Q[int]
+	assert.PanicsWithValue(t, "invalid count: -1", func() { q.PopN(nil, -1) })
+	assert.PanicsWithValue(t, "invalid count: -1", func() { q.PeekN(nil, -1) })
+	assert.PanicsWithValue(t, "invalid count: -1", func() { q.DiscardN(-1) })
+	assert.PanicsWithValue(t, "invalid count: -1", func() { q.Grow(-1) })
+}
+
+func TestQ_InsertRemove(t *testing.T) {
+	t.Parallel()
+
+	q := ring.NewQ[int](8)
+	q.PushSlice(0, 1, 2, 3, 4)
+
+	q.Insert(1, 10) // front half
+	q.Insert(5, 20) // back half
+	q.Insert(0, 30)
+	q.Insert(q.Len(), 40)
+	assert.Equal(t, []int{30, 0, 10, 1, 2, 3, 20, 4, 40}, q.Snapshot(nil))
+
+	assert.Equal(t, 10, q.Remove(2), "remove front half")
+	assert.Equal(t, 20, q.Remove(5), "remove back half")
+	assert.Equal(t, []int{30, 0, 1, 2, 3, 4, 40}, q.Snapshot(nil))
+
+	q.RemoveRange(0, 2) // front
+	q.RemoveRange(3, 5) // back
+	q.RemoveRange(1, 1) // empty
+	assert.Equal(t, []int{1, 2, 3}, q.Snapshot(nil))
+
+	assert.PanicsWithValue(t, "index out of range [4] with length 3", func() {
+		q.Insert(4, 42)
+	}, "insert")
+	assert.PanicsWithValue(t, "index out of range [3] with length 3", func() {
+		q.Remove(3)
+	}, "remove")
+	assert.PanicsWithValue(t, "range out of bounds [2:1] with length 3", func() {
+		q.RemoveRange(2, 1)
+	}, "remove range")
+}
+
+func TestQ_Rotate_full(t *testing.T) {
+	t.Parallel()
+
+	want := []int{1, 2, 3, 4, 5}
+	for offset := range 6 {
+		for n := -6; n <= 6; n++ {
+			// Start the items at every offset in the buffer
+			// so rotations cross the wraparound.
+			q := ring.NewQ[int](5)
+			for range offset {
+				q.Push(0)
+				q.Pop()
+			}
+			q.PushSlice(want...)
+			capacity := q.Cap()
+			require.Equal(t, q.Len(), capacity, "queue must be full")
+
+			q.Rotate(n)
+
+			k := ((n % 5) + 5) % 5
+			assert.Equal(t, append(slices.Clone(want[k:]), want[:k]...), q.Snapshot(nil),
+				"offset=%d n=%d", offset, n)
+			assert.Equal(t, capacity, q.Cap(), "rotation must not grow the queue")
+		}
+	}
+}
+
+func TestQ_Rotate_fullNoAlloc(t *testing.T) {
+	q := ring.NewQ[int](64)
+	for i := range 64 {
+		q.Push(i)
+	}
+
+	allocs := testing.AllocsPerRun(100, func() {
+		q.Rotate(7)
+		q.Rotate(-30)
+	})
+	assert.Zero(t, allocs)
+}
+
+func TestQ_ReverseSwap(t *testing.T) {
+	t.Parallel()
+
+	q := ring.NewQ[int](4)
+	q.PushSlice(0, 0, 1, 2)
+	q.DiscardN(2)
+	q.PushSlice(3, 4) // wraps around
+
+	q.Reverse()
+	assert.Equal(t, []int{4, 3, 2, 1}, q.Snapshot(nil))
+
+	q.Swap(0, 3)
+	assert.Equal(t, []int{1, 3, 2, 4}, q.Snapshot(nil))
+
+	assert.PanicsWithValue(t, "index out of range [4] with length 4", func() {
+		q.Swap(0, 4)
+	})
+}
@@ -1,7 +1,10 @@
 package ring_test
 
 import (
+	"encoding"
+	"encoding/json"
 	"fmt"
+	"iter"
 	"reflect"
 	"sort"
 	"strings"
@@ -28,14 +31,52 @@ func TestMuQ(t *testing.T) {
 	})
 }
 
+func TestQ_suite_shrinkPolicy(t *testing.T) {
+	t.Parallel()
+
+	testQueueSuite(t, func(capacity int) queue[int] {
+		return ring.NewQWithOptions[int](capacity,
+			ring.WithShrinkPolicy(ring.ShrinkHalfAtQuarter))
+	})
+}
+
+func TestMuQ_suite_shrinkPolicy(t *testing.T) {
+	t.Parallel()
+
+	testQueueSuite(t, func(capacity int) queue[int] {
+		return ring.NewMuQWithOptions[int](capacity,
+			ring.WithShrinkPolicy(ring.ShrinkHalfAtQuarter))
+	})
+}
+
 type queue[T any] interface {
 	Empty() bool
 	Len() int
+	Cap() int
+	Grow(n int)
 	Clear()
 	Push(x T)
+	PushSlice(xs ...T)
 	TryPop() (T, bool)
+	PopN(dst []T, n int) []T
+	PeekN(dst []T, n int) []T
+	DiscardN(n int) int
+	RetainFunc(keep func(T) bool) int
+	DeleteFunc(del func(T) bool) int
+	Rotate(n int)
+	Reverse()
 	TryPeek() (T, bool)
+	TryBack() (T, bool)
+	TryAt(int) (T, bool)
 	Snapshot([]T) []T
+	All() iter.Seq2[int, T]
+
+	json.Marshaler
+	json.Unmarshaler
+	encoding.BinaryMarshaler
+	encoding.BinaryUnmarshaler
+	encoding.TextMarshaler
+	encoding.TextUnmarshaler
 }
 
 var (
@@ -5,6 +5,7 @@ import (
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 	"go.abhg.dev/container/ring"
 	"pgregory.net/rapid"
 )
@@ -54,6 +55,7 @@ func newQMachine[QT queue[int]](t *rapid.T) *qMachine[QT] {
 
 func (m *qMachine[QT]) Check(t *rapid.T) {
 	assert.Equal(t, m.q.Len(), m.golden.Len())
+	assert.GreaterOrEqual(t, m.q.Cap(), m.q.Len())
 
 	got := make([]int, 0, m.q.Len())
 	got = m.q.Snapshot(got)
@@ -132,3 +134,363 @@ func (m *qMachine[QT]) Snapshot(t *rapid.T) {
 		assert.Equal(t, e.Value, got[i])
 	}
 }
+
+func (m *qMachine[QT]) All(t *rapid.T) {
+	e := m.golden.Front()
+	for i, x := range m.q.All() {
+		if !assert.NotNil(t, e, "too many items") {
+			return
+		}
+		assert.Equal(t, e.Value, x, "item %d", i)
+		e = e.Next()
+	}
+	assert.Nil(t, e, "too few items")
+}
+
+func (m *qMachine[QT]) TryBack(t *rapid.T) {
+	got, ok := m.q.TryBack()
+
+	back := m.golden.Back()
+	if back == nil {
+		assert.False(t, ok)
+		return
+	}
+
+	assert.True(t, ok)
+	assert.Equal(t, back.Value, got)
+}
+
+func (m *qMachine[QT]) TryAt(t *rapid.T) {
+	i := rapid.IntRange(-1, m.golden.Len()).Draw(t, "i")
+	got, ok := m.q.TryAt(i)
+
+	e := goldenAt(m.golden, i)
+	if e == nil {
+		assert.False(t, ok)
+		return
+	}
+
+	assert.True(t, ok)
+	assert.Equal(t, e.Value, got)
+}
+
+func (m *qMachine[QT]) Set(t *rapid.T) {
+	i := rapid.IntRange(-1, m.golden.Len()).Draw(t, "i")
+	x := rapid.Int().Draw(t, "x")
+
+	e := goldenAt(m.golden, i)
+	switch q := any(m.q).(type) {
+	case *ring.Q[int]:
+		if e == nil {
+			assert.Panics(t, func() { q.Set(i, x) })
+			return
+		}
+		q.Set(i, x)
+
+	case *ring.MuQ[int]:
+		if !q.TrySet(i, x) {
+			assert.Nil(t, e)
+			return
+		}
+		assert.NotNil(t, e)
+
+	default:
+		t.Fatalf("unexpected queue type: %T", m.q)
+	}
+
+	e.Value = x
+}
+
+// goldenAt returns the element at position i in l,
+// or nil if i is out of range.
+func goldenAt(l *list.List, i int) *list.Element {
+	if i < 0 || i >= l.Len() {
+		return nil
+	}
+
+	e := l.Front()
+	for ; i > 0; i-- {
+		e = e.Next()
+	}
+	return e
+}
+
+func (m *qMachine[QT]) PushSlice(t *rapid.T) {
+	xs := rapid.SliceOf(rapid.Int()).Draw(t, "xs")
+	m.q.PushSlice(xs...)
+	for _, x := range xs {
+		m.golden.PushBack(x)
+	}
+}
+
+func (m *qMachine[QT]) PopN(t *rapid.T) {
+	n := rapid.IntRange(0, m.golden.Len()+2).Draw(t, "n")
+	got := m.q.PopN([]int{-1}, n)
+
+	assert.Equal(t, -1, got[0], "dst must be preserved")
+	got = got[1:]
+	assert.Len(t, got, min(n, m.golden.Len()))
+	for _, x := range got {
+		assert.Equal(t, m.golden.Remove(m.golden.Front()), x)
+	}
+}
+
+func (m *qMachine[QT]) PeekN(t *rapid.T) {
+	n := rapid.IntRange(0, m.golden.Len()+2).Draw(t, "n")
+	got := m.q.PeekN(nil, n)
+
+	assert.Len(t, got, min(n, m.golden.Len()))
+	e := m.golden.Front()
+	for _, x := range got {
+		assert.Equal(t, e.Value, x)
+		e = e.Next()
+	}
+}
+
+func (m *qMachine[QT]) DiscardN(t *rapid.T) {
+	n := rapid.IntRange(0, m.golden.Len()+2).Draw(t, "n")
+	want := min(n, m.golden.Len())
+	assert.Equal(t, want, m.q.DiscardN(n))
+	for i := 0; i < want; i++ {
+		m.golden.Remove(m.golden.Front())
+	}
+}
+
+func (m *qMachine[QT]) Grow(t *rapid.T) {
+	n := rapid.IntRange(0, 100).Draw(t, "n")
+	m.q.Grow(n)
+	assert.GreaterOrEqual(t, m.q.Cap(), m.q.Len()+n)
+}
+
+func (m *qMachine[QT]) Insert(t *rapid.T) {
+	i := rapid.IntRange(-1, m.golden.Len()+1).Draw(t, "i")
+	x := rapid.Int().Draw(t, "x")
+
+	valid := i >= 0 && i <= m.golden.Len()
+	switch q := any(m.q).(type) {
+	case *ring.Q[int]:
+		if !valid {
+			assert.Panics(t, func() { q.Insert(i, x) })
+			return
+		}
+		q.Insert(i, x)
+
+	case *ring.MuQ[int]:
+		ok := q.TryInsert(i, x)
+		assert.Equal(t, valid, ok)
+		if !ok {
+			return
+		}
+
+	default:
+		t.Fatalf("unexpected queue type: %T", m.q)
+	}
+
+	if e := goldenAt(m.golden, i); e != nil {
+		m.golden.InsertBefore(x, e)
+	} else {
+		m.golden.PushBack(x)
+	}
+}
+
+func (m *qMachine[QT]) Remove(t *rapid.T) {
+	i := rapid.IntRange(-1, m.golden.Len()).Draw(t, "i")
+
+	e := goldenAt(m.golden, i)
+	var got int
+	switch q := any(m.q).(type) {
+	case *ring.Q[int]:
+		if e == nil {
+			assert.Panics(t, func() { q.Remove(i) })
+			return
+		}
+		got = q.Remove(i)
+
+	case *ring.MuQ[int]:
+		var ok bool
+		got, ok = q.TryRemove(i)
+		assert.Equal(t, e != nil, ok)
+		if !ok {
+			return
+		}
+
+	default:
+		t.Fatalf("unexpected queue type: %T", m.q)
+	}
+
+	assert.Equal(t, m.golden.Remove(e), got)
+}
+
+func (m *qMachine[QT]) RemoveRange(t *rapid.T) {
+	i := rapid.IntRange(-1, m.golden.Len()+1).Draw(t, "i")
+	j := rapid.IntRange(-1, m.golden.Len()+1).Draw(t, "j")
+
+	valid := i >= 0 && j <= m.golden.Len() && i <= j
+	switch q := any(m.q).(type) {
+	case *ring.Q[int]:
+		if !valid {
+			assert.Panics(t, func() { q.RemoveRange(i, j) })
+			return
+		}
+		q.RemoveRange(i, j)
+
+	case *ring.MuQ[int]:
+		ok := q.TryRemoveRange(i, j)
+		assert.Equal(t, valid, ok)
+		if !ok {
+			return
+		}
+
+	default:
+		t.Fatalf("unexpected queue type: %T", m.q)
+	}
+
+	e := goldenAt(m.golden, i)
+	for k := i; k < j; k++ {
+		next := e.Next()
+		m.golden.Remove(e)
+		e = next
+	}
+}
+
+func (m *qMachine[QT]) RetainFunc(t *rapid.T) {
+	mod := rapid.IntRange(1, 4).Draw(t, "mod")
+	keep := func(x int) bool { return x%mod == 0 }
+
+	var want int
+	for e := m.golden.Front(); e != nil; {
+		next := e.Next()
+		if !keep(e.Value.(int)) {
+			m.golden.Remove(e)
+			want++
+		}
+		e = next
+	}
+
+	assert.Equal(t, want, m.q.RetainFunc(keep))
+}
+
+func (m *qMachine[QT]) DeleteFunc(t *rapid.T) {
+	mod := rapid.IntRange(1, 4).Draw(t, "mod")
+	del := func(x int) bool { return x%mod == 0 }
+
+	var want int
+	for e := m.golden.Front(); e != nil; {
+		next := e.Next()
+		if del(e.Value.(int)) {
+			m.golden.Remove(e)
+			want++
+		}
+		e = next
+	}
+
+	assert.Equal(t, want, m.q.DeleteFunc(del))
+}
+
+func (m *qMachine[QT]) Segments(t *rapid.T) {
+	q, ok := any(m.q).(*ring.Q[int])
+	if !ok {
+		t.Skip("not supported")
+	}
+
+	a, b := q.Segments()
+	got := append(append([]int(nil), a...), b...)
+	assert.Len(t, got, m.golden.Len())
+	for i, e := 0, m.golden.Front(); e != nil; i, e = i+1, e.Next() {
+		assert.Equal(t, e.Value, got[i])
+	}
+}
+
+func (m *qMachine[QT]) MakeContiguous(t *rapid.T) {
+	q, ok := any(m.q).(*ring.Q[int])
+	if !ok {
+		t.Skip("not supported")
+	}
+
+	got := q.MakeContiguous()
+	assert.Len(t, got, m.golden.Len())
+	for i, e := 0, m.golden.Front(); e != nil; i, e = i+1, e.Next() {
+		assert.Equal(t, e.Value, got[i])
+	}
+}
+
+func (m *qMachine[QT]) Rotate(t *rapid.T) {
+	n := rapid.IntRange(-2*m.golden.Len()-1, 2*m.golden.Len()+1).Draw(t, "n")
+	m.q.Rotate(n)
+
+	if m.golden.Len() == 0 {
+		return
+	}
+	k := n % m.golden.Len()
+	if k < 0 {
+		k += m.golden.Len()
+	}
+	for ; k > 0; k-- {
+		m.golden.MoveToBack(m.golden.Front())
+	}
+}
+
+func (m *qMachine[QT]) Reverse(_ *rapid.T) {
+	m.q.Reverse()
+
+	reversed := list.New()
+	for e := m.golden.Front(); e != nil; e = e.Next() {
+		reversed.PushFront(e.Value)
+	}
+	m.golden = reversed
+}
+
+func (m *qMachine[QT]) Swap(t *rapid.T) {
+	i := rapid.IntRange(-1, m.golden.Len()).Draw(t, "i")
+	j := rapid.IntRange(-1, m.golden.Len()).Draw(t, "j")
+
+	ei, ej := goldenAt(m.golden, i), goldenAt(m.golden, j)
+	valid := ei != nil && ej != nil
+	switch q := any(m.q).(type) {
+	case *ring.Q[int]:
+		if !valid {
+			assert.Panics(t, func() { q.Swap(i, j) })
+			return
+		}
+		q.Swap(i, j)
+
+	case *ring.MuQ[int]:
+		ok := q.TrySwap(i, j)
+		assert.Equal(t, valid, ok)
+		if !ok {
+			return
+		}
+
+	default:
+		t.Fatalf("unexpected queue type: %T", m.q)
+	}
+
+	ei.Value, ej.Value = ej.Value, ei.Value
+}
+
+func (m *qMachine[QT]) RoundTripJSON(t *rapid.T) {
+	data, err := m.q.MarshalJSON()
+	require.NoError(t, err)
+
+	// Decode back into the same queue:
+	// Check verifies that the contents survived the round trip.
+	require.NoError(t, m.q.UnmarshalJSON(data))
+}
+
+func (m *qMachine[QT]) RoundTripText(t *rapid.T) {
+	data, err := m.q.MarshalText()
+	require.NoError(t, err)
+
+	// Decode back into the same queue:
+	// Check verifies that the contents survived the round trip.
+	require.NoError(t, m.q.UnmarshalText(data))
+}
+
+func (m *qMachine[QT]) RoundTripBinary(t *rapid.T) {
+	data, err := m.q.MarshalBinary()
+	require.NoError(t, err)
+
+	// Decode back into the same queue:
+	// Check verifies that the contents survived the round trip.
+	require.NoError(t, m.q.UnmarshalBinary(data))
+}
@@ -0,0 +1,161 @@
+package ring
+
+import (
+	"context"
+	"reflect"
+)
+
+// Ready returns a channel that receives a value
+// when the queue goes from empty to non-empty,
+// or when the queue is closed.
+// If the queue is non-empty or closed when Ready is first called,
+// the channel receives a value right away.
+//
+// Use it to wait for items in a select statement
+// alongside other channels:
+//
+//	for {
+//		job, ok, closed := jobs.TryPopClosed()
+//		if ok {
+//			job.Run()
+//			continue
+//		}
+//		if closed {
+//			return nil // drained
+//		}
+//
+//		select {
+//		case <-jobs.Ready():
+//		case <-ticker.C:
+//			reportProgress()
+//		case <-ctx.Done():
+//			return ctx.Err()
+//		}
+//	}
+//
+// The channel holds at most one value,
+// and it's not signaled again for additions to a non-empty queue,
+// so after receiving from it, keep removing items until the queue is empty.
+// Wake-ups may be spurious:
+// the queue may be empty again by the time the value is received
+// if another goroutine removed the items.
+//
+// No wake-ups are lost if an item is added
+// between checking the queue and receiving from the channel:
+// the value waits in the channel until it's received.
+//
+// Every call to Ready returns the same channel.
+// Multiple goroutines may receive from it,
+// but each value wakes only one of them.
+// If several goroutines need to wait for items, prefer [MuQ.PopWait].
+func (q *MuQ[T]) Ready() <-chan struct{} {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	if q.ready == nil {
+		q.ready = make(chan struct{}, 1)
+		if !q.q.Empty() || q.closed {
+			q.ready <- struct{}{}
+		}
+	}
+	return q.ready
+}
+
+// signalReady sends a value on the channel returned by Ready
+// unless it already holds one.
+// It does nothing if Ready hasn't been called.
+//
+// The caller must hold the write lock.
+func (q *MuQ[T]) signalReady() {
+	select {
+	case q.ready <- struct{}{}:
+	default:
+		// Either the channel is nil or it already holds a value.
+	}
+}
+
+// WaitAny blocks until one of the given queues is non-empty,
+// and returns its index in qs.
+// If more than one queue is non-empty, the one that comes first in qs wins.
+//
+// The queue may be empty again by the time the caller removes an item from it
+// if other goroutines are also removing items,
+// so use TryPop and call WaitAny again if it fails.
+//
+// WaitAny returns -1 and ctx.Err() if ctx is done before any queue has items.
+// It returns -1 and [ErrClosed] if all queues are empty and closed,
+// as no items will ever be added to them,
+// or if qs is empty.
+//
+// WaitAny waits on the channels returned by [MuQ.Ready].
+// If it's woken up by one queue but returns another,
+// it signals the first queue's channel again
+// so that other goroutines receiving from it are not left waiting.
+func WaitAny[T any](ctx context.Context, qs ...*MuQ[T]) (int, error) {
+	// One case per queue, and one for ctx.Done().
+	cases := make([]reflect.SelectCase, len(qs)+1)
+	for i, q := range qs {
+		cases[i] = reflect.SelectCase{
+			Dir:  reflect.SelectRecv,
+			Chan: reflect.ValueOf(q.Ready()),
+		}
+	}
+	doneIdx := len(qs)
+	cases[doneIdx] = reflect.SelectCase{Dir: reflect.SelectRecv}
+	if done := ctx.Done(); done != nil {
+		cases[doneIdx].Chan = reflect.ValueOf(done)
+	}
+
+	woken := -1 // queue whose Ready signal was last received
+	for {
+		// Check the queues before waiting,
+		// and again after every wake-up, as they may be spurious.
+		open := false
+		for i, q := range qs {
+			empty, closed := q.state()
+			if !empty {
+				if woken >= 0 && woken != i {
+					qs[woken].resignalReady()
+				}
+				return i, nil
+			}
+			if closed {
+				// Empty and closed: it will never have items again.
+				// A zero Chan disables the case.
+				cases[i].Chan = reflect.Value{}
+			} else {
+				open = true
+			}
+		}
+		if !open {
+			if woken >= 0 {
+				qs[woken].resignalReady()
+			}
+			return -1, ErrClosed
+		}
+
+		chosen, _, _ := reflect.Select(cases)
+		if chosen == doneIdx {
+			return -1, ctx.Err()
+		}
+		woken = chosen
+	}
+}
+
+// resignalReady signals the channel returned by Ready again
+// if the queue has items or is closed.
+// It's used after a signal was received but not acted on.
+func (q *MuQ[T]) resignalReady() {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	if !q.q.Empty() || q.closed {
+		q.signalReady()
+	}
+}
+
+// state reports whether the queue is empty and whether it's closed
+// under a single read lock.
+func (q *MuQ[T]) state() (empty, closed bool) {
+	q.mu.RLock()
+	defer q.mu.RUnlock()
+	return q.q.Empty(), q.closed
+}
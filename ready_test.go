@@ -0,0 +1,331 @@
+package ring_test
+
+import (
+	"context"
+	"runtime"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.abhg.dev/container/ring"
+)
+
+// isReady reports whether ch holds a value, consuming it.
+func isReady(ch <-chan struct{}) bool {
+	select {
+	case <-ch:
+		return true
+	default:
+		return false
+	}
+}
+
+func TestMuQ_Ready(t *testing.T) {
+	t.Parallel()
+
+	t.Run("EmptyToNonEmpty", func(t *testing.T) {
+		t.Parallel()
+
+		var q ring.MuQ[int]
+		ready := q.Ready()
+		assert.Equal(t, ready, q.Ready())
+		assert.False(t, isReady(ready))
+
+		q.Push(1)
+		assert.True(t, isReady(ready))
+
+		// Not signaled for additions to a non-empty queue.
+		q.PushSlice(2, 3)
+		assert.False(t, isReady(ready))
+
+		// Signaled again after the queue has been emptied.
+		q.Clear()
+		q.Push(4)
+		assert.True(t, isReady(ready))
+		assert.False(t, isReady(ready))
+	})
+
+	t.Run("NonEmptyOnFirstCall", func(t *testing.T) {
+		t.Parallel()
+
+		q := ring.MuQFromSlice([]int{1})
+		assert.True(t, isReady(q.Ready()))
+	})
+
+	t.Run("Close", func(t *testing.T) {
+		t.Parallel()
+
+		var q ring.MuQ[int]
+		ready := q.Ready()
+		q.Close()
+		assert.True(t, isReady(ready))
+
+		var closed ring.MuQ[int]
+		closed.Close()
+		assert.True(t, isReady(closed.Ready()))
+	})
+
+	t.Run("AllAdditions", func(t *testing.T) {
+		t.Parallel()
+
+		add := map[string]func(t *testing.T, q *ring.MuQ[int]){
+			"Push":         func(_ *testing.T, q *ring.MuQ[int]) { q.Push(1) },
+			"TryPush":      func(_ *testing.T, q *ring.MuQ[int]) { q.TryPush(1) },
+			"PushSlice":    func(_ *testing.T, q *ring.MuQ[int]) { q.PushSlice(1, 2) },
+			"TryPushSlice": func(_ *testing.T, q *ring.MuQ[int]) { q.TryPushSlice(1, 2) },
+			"TryInsert":    func(_ *testing.T, q *ring.MuQ[int]) { q.TryInsert(0, 1) },
+			"AppendQueue": func(_ *testing.T, q *ring.MuQ[int]) {
+				q.AppendQueue(ring.MuQFromSlice([]int{1}))
+			},
+			"UnmarshalJSON": func(t *testing.T, q *ring.MuQ[int]) {
+				assert.NoError(t, q.UnmarshalJSON([]byte(`[1]`)))
+			},
+		}
+
+		for name, add := range add {
+			t.Run(name, func(t *testing.T) {
+				t.Parallel()
+
+				var q ring.MuQ[int]
+				ready := q.Ready()
+				add(t, &q)
+				assert.True(t, isReady(ready))
+			})
+		}
+	})
+
+	// A producer pushing while the consumer is between
+	// TryPop and receiving from Ready must not leave the consumer blocked.
+	t.Run("NoLostWakeups", func(t *testing.T) {
+		t.Parallel()
+
+		const N = 10_000
+
+		var q ring.MuQ[int]
+		go func() {
+			for i := range N {
+				q.Push(i)
+			}
+		}()
+
+		got := 0
+		timeout := time.After(10 * time.Second)
+		for got < N {
+			if _, ok := q.TryPop(); ok {
+				got++
+				continue
+			}
+
+			select {
+			case <-q.Ready():
+			case <-timeout:
+				t.Fatalf("lost wake-up after %d items", got)
+			}
+		}
+	})
+}
+
+func TestWaitAny(t *testing.T) {
+	t.Parallel()
+
+	t.Run("AlreadyNonEmpty", func(t *testing.T) {
+		t.Parallel()
+
+		var a, b ring.MuQ[int]
+		b.Push(1)
+
+		i, err := ring.WaitAny(context.Background(), &a, &b)
+		require.NoError(t, err)
+		assert.Equal(t, 1, i)
+	})
+
+	t.Run("FirstWins", func(t *testing.T) {
+		t.Parallel()
+
+		a, b := ring.MuQFromSlice([]int{1}), ring.MuQFromSlice([]int{2})
+		i, err := ring.WaitAny(context.Background(), a, b)
+		require.NoError(t, err)
+		assert.Equal(t, 0, i)
+	})
+
+	t.Run("Blocks", func(t *testing.T) {
+		t.Parallel()
+
+		var a, b, c ring.MuQ[int]
+		result := make(chan int)
+		go func() {
+			i, err := ring.WaitAny(context.Background(), &a, &b, &c)
+			assert.NoError(t, err)
+			result <- i
+		}()
+
+		c.Push(1)
+		assert.Equal(t, 2, <-result)
+	})
+
+	t.Run("SpuriousWakeup", func(t *testing.T) {
+		t.Parallel()
+
+		// a has a stale signal: its item was removed
+		// without receiving from Ready.
+		var a, b ring.MuQ[int]
+		a.Push(1)
+		a.TryPop()
+
+		result := make(chan int)
+		go func() {
+			i, err := ring.WaitAny(context.Background(), &a, &b)
+			assert.NoError(t, err)
+			result <- i
+		}()
+
+		b.Push(2)
+		assert.Equal(t, 1, <-result)
+	})
+
+	t.Run("Context", func(t *testing.T) {
+		t.Parallel()
+
+		var a ring.MuQ[int]
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+		defer cancel()
+
+		i, err := ring.WaitAny(ctx, &a)
+		assert.ErrorIs(t, err, context.DeadlineExceeded)
+		assert.Equal(t, -1, i)
+	})
+
+	t.Run("Closed", func(t *testing.T) {
+		t.Parallel()
+
+		var a, b ring.MuQ[int]
+		a.Close()
+
+		result := make(chan error)
+		go func() {
+			_, err := ring.WaitAny(context.Background(), &a, &b)
+			result <- err
+		}()
+
+		b.Close()
+		assert.ErrorIs(t, <-result, ring.ErrClosed)
+	})
+
+	t.Run("ClosedNonEmpty", func(t *testing.T) {
+		t.Parallel()
+
+		a := ring.MuQFromSlice([]int{1})
+		a.Close()
+
+		i, err := ring.WaitAny(context.Background(), a)
+		require.NoError(t, err)
+		assert.Equal(t, 0, i)
+	})
+
+	t.Run("NoQueues", func(t *testing.T) {
+		t.Parallel()
+
+		i, err := ring.WaitAny[int](context.Background())
+		assert.ErrorIs(t, err, ring.ErrClosed)
+		assert.Equal(t, -1, i)
+	})
+}
+
+// Mixes WaitAny with a direct receiver on Ready,
+// and verifies that WaitAny doesn't take the Ready signal of a queue
+// that still has items but isn't the one it returned.
+func TestWaitAny_ready(t *testing.T) {
+	t.Parallel()
+
+	for range 1000 {
+		var a, b ring.MuQ[int]
+
+		result := make(chan int)
+		go func() {
+			i, err := ring.WaitAny(context.Background(), &a, &b)
+			assert.NoError(t, err)
+			result <- i
+		}()
+		runtime.Gosched()
+
+		// WaitAny may wake up for b and find that a has an item too.
+		b.Push(1)
+		a.Push(1)
+		if <-result == 1 {
+			// WaitAny received b's signal and returned it.
+			continue
+		}
+
+		select {
+		case <-b.Ready():
+		default:
+			t.Fatal("b has items but Ready was not signaled")
+		}
+	}
+}
+
+// Runs producers on several queues and consumers using WaitAny
+// and verifies that every item is consumed exactly once.
+func TestWaitAny_concurrent(t *testing.T) {
+	t.Parallel()
+
+	const (
+		Queues    = 4
+		Consumers = 4
+		Items     = 1000 // per queue
+	)
+
+	qs := make([]*ring.MuQ[int], Queues)
+	for i := range qs {
+		qs[i] = new(ring.MuQ[int])
+	}
+
+	var producers sync.WaitGroup
+	for i, q := range qs {
+		producers.Add(1)
+		go func() {
+			defer producers.Done()
+			for j := range Items {
+				q.Push(i*Items + j)
+			}
+			q.Close()
+		}()
+	}
+
+	var (
+		mu   sync.Mutex
+		seen = make(map[int]int)
+	)
+	var consumers sync.WaitGroup
+	for range Consumers {
+		consumers.Add(1)
+		go func() {
+			defer consumers.Done()
+			for {
+				i, err := ring.WaitAny(context.Background(), qs...)
+				if err != nil {
+					assert.ErrorIs(t, err, ring.ErrClosed)
+					return
+				}
+
+				x, ok := qs[i].TryPop()
+				if !ok {
+					continue // another consumer got it
+				}
+				mu.Lock()
+				seen[x]++
+				mu.Unlock()
+			}
+		}()
+	}
+
+	producers.Wait()
+	consumers.Wait()
+
+	assert.Len(t, seen, Queues*Items)
+	for x, n := range seen {
+		assert.Equal(t, 1, n, "item %d", x)
+	}
+}
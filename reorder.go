@@ -0,0 +1,108 @@
+package ring
+
+// Rotate moves the first n items of the queue to the back,
+// keeping their order.
+// If n is negative, it moves the last -n items to the front instead.
+// n may be larger than the length of the queue;
+// rotating by Len() leaves the queue unchanged.
+//
+//	// q = [1 2 3 4 5]
+//	q.Rotate(2)  // q = [3 4 5 1 2]
+//	q.Rotate(-1) // q = [2 3 4 5 1]
+//
+// Rotate moves items across the ends of the queue one slot at a time,
+// in whichever direction requires fewer moves.
+// This is an O(min(k, Len()-k)) operation, where k is n modulo Len(),
+// and it never allocates, even if the queue is full.
+//
+// Rotation can't be done by moving head and tail alone,
+// even when the queue is full.
+// The buffer always keeps one slot empty
+// to tell a full queue apart from an empty one,
+// and after rotating, that slot must sit between the new back
+// and the new front of the queue.
+// Getting it there takes min(k, Len()-k) moves.
+func (q *Q[T]) Rotate(n int) {
+	length := q.Len()
+	if length == 0 {
+		return
+	}
+
+	k := n % length
+	if k < 0 {
+		k += length
+	}
+	if k == 0 {
+		return
+	}
+
+	q.mutate()
+	var zero T
+	if k <= length-k {
+		// Move k items from the front to the back.
+		for ; k > 0; k-- {
+			q.buff[q.tail] = q.buff[q.head]
+			q.buff[q.head] = zero
+			q.head = q.next(q.head)
+			q.tail = q.next(q.tail)
+		}
+	} else {
+		// Move length-k items from the back to the front.
+		for k = length - k; k > 0; k-- {
+			q.head = q.prev(q.head)
+			q.tail = q.prev(q.tail)
+			q.buff[q.head] = q.buff[q.tail]
+			q.buff[q.tail] = zero
+		}
+	}
+}
+
+// Reverse reverses the order of the items in the queue in place.
+//
+// This is an O(n) operation and does not allocate.
+func (q *Q[T]) Reverse() {
+	if q.Len() < 2 {
+		return
+	}
+	q.mutate()
+	for i, j := 0, q.Len()-1; i < j; i, j = i+1, j-1 {
+		q.swap(i, j)
+	}
+}
+
+// Swap swaps the items at positions i and j in the queue.
+// It panics if i or j are out of range.
+//
+// This is an O(1) operation and does not allocate.
+func (q *Q[T]) Swap(i, j int) {
+	q.checkIndex(i)
+	q.checkIndex(j)
+	if i == j {
+		return
+	}
+	q.mutate()
+	q.swap(i, j)
+}
+
+// swap swaps the items at positions i and j without bounds checks.
+func (q *Q[T]) swap(i, j int) {
+	i, j = q.index(i), q.index(j)
+	q.buff[i], q.buff[j] = q.buff[j], q.buff[i]
+}
+
+// next returns the index in buff after idx, wrapping around.
+func (q *Q[T]) next(idx int) int {
+	idx++
+	if idx == len(q.buff) {
+		idx = 0
+	}
+	return idx
+}
+
+// prev returns the index in buff before idx, wrapping around.
+func (q *Q[T]) prev(idx int) int {
+	if idx == 0 {
+		idx = len(q.buff)
+	}
+	return idx - 1
+}
@@ -0,0 +1,100 @@
+package ring
+
+import (
+	"cmp"
+	"slices"
+	"sort"
+)
+
+// Sort sorts the items in the queue in ascending order,
+// with the front of the queue holding the smallest item.
+// It behaves like [slices.Sort].
+//
+// Sorting happens in place across both ends of the ring buffer,
+// without first rearranging it into a single slice.
+func Sort[T cmp.Ordered](q *Q[T]) {
+	q.SortFunc(cmp.Compare[T])
+}
+
+// SortFunc sorts the items in the queue in ascending order
+// as determined by the cmp function.
+// It behaves like [slices.SortFunc]:
+// cmp(a, b) should return a negative number when a < b,
+// a positive number when a > b, and zero when a == b.
+// The sort is not guaranteed to be stable.
+//
+// Sorting happens in place across both ends of the ring buffer,
+// without first rearranging it into a single slice.
+func (q *Q[T]) SortFunc(cmp func(a, b T) int) {
+	if q.Len() < 2 {
+		return
+	}
+
+	q.mutate()
+	if q.head <= q.tail {
+		slices.SortFunc(q.buff[q.head:q.tail], cmp)
+		return
+	}
+	sort.Sort(&ringSorter[T]{q: q, cmp: cmp})
+}
+
+// SortStableFunc sorts the items in the queue
+// as determined by the cmp function,
+// keeping the original order of equal items.
+// It behaves like [slices.SortStableFunc].
+//
+// Sorting happens in place across both ends of the ring buffer,
+// without first rearranging it into a single slice.
+func (q *Q[T]) SortStableFunc(cmp func(a, b T) int) {
+	if q.Len() < 2 {
+		return
+	}
+
+	q.mutate()
+	if q.head <= q.tail {
+		slices.SortStableFunc(q.buff[q.head:q.tail], cmp)
+		return
+	}
+	sort.Stable(&ringSorter[T]{q: q, cmp: cmp})
+}
+
+// BinarySearch searches for target in a queue sorted in ascending order
+// and returns the position where target is found,
+// or the position where it would appear in sort order.
+// It also returns a bool reporting whether target was found.
+// It behaves like [slices.BinarySearch].
+func BinarySearch[T cmp.Ordered](q *Q[T], target T) (int, bool) {
+	return BinarySearchFunc(q, target, cmp.Compare[T])
+}
+
+// BinarySearchFunc is like [BinarySearch],
+// but uses a custom comparison function.
+// The queue must be sorted in increasing order,
+// where "increasing" is defined by cmp.
+// cmp should return 0 if the queue item matches the target,
+// a negative number if the queue item precedes the target,
+// or a positive number if the queue item follows the target.
+// It behaves like [slices.BinarySearchFunc].
+func BinarySearchFunc[E, T any](q *Q[E], target T, cmp func(E, T) int) (int, bool) {
+	n := q.Len()
+	i := sort.Search(n, func(i int) bool {
+		return cmp(q.buff[q.index(i)], target) >= 0
+	})
+	return i, i < n && cmp(q.buff[q.index(i)], target) == 0
+}
+
+// ringSorter adapts a wrapped-around queue to sort.Interface.
+type ringSorter[T any] struct {
+	q   *Q[T]
+	cmp func(a, b T) int
+}
+
+var _ sort.Interface = (*ringSorter[int])(nil)
+
+func (s *ringSorter[T]) Len() int { return s.q.Len() }
+
+func (s *ringSorter[T]) Swap(i, j int) { s.q.swap(i, j) }
+
+func (s *ringSorter[T]) Less(i, j int) bool {
+	return s.cmp(s.q.buff[s.q.index(i)], s.q.buff[s.q.index(j)]) < 0
+}
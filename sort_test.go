@@ -0,0 +1,115 @@
+package ring_test
+
+import (
+	"cmp"
+	"slices"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"go.abhg.dev/container/ring"
+	"pgregory.net/rapid"
+)
+
+// Sorting must work the same way
+// whether the queue contents wrap around or not.
+func TestQ_sort_rapid(t *testing.T) {
+	t.Parallel()
+
+	t.Run("Sort", rapid.MakeCheck(func(t *rapid.T) {
+		items, q := drawSortQ(t, rapid.IntRange(-10, 10))
+		ring.Sort(q)
+
+		slices.Sort(items)
+		assert.Equal(t, items, q.Snapshot(make([]int, 0)))
+	}))
+
+	t.Run("SortFunc", rapid.MakeCheck(func(t *rapid.T) {
+		items, q := drawSortQ(t, rapid.IntRange(-10, 10))
+		desc := func(a, b int) int { return cmp.Compare(b, a) }
+		q.SortFunc(desc)
+
+		slices.SortFunc(items, desc)
+		assert.Equal(t, items, q.Snapshot(make([]int, 0)))
+	}))
+
+	t.Run("SortStableFunc", rapid.MakeCheck(func(t *rapid.T) {
+		// Items are sorted by value only,
+		// so equal values must keep their original order.
+		type item struct{ value, pos int }
+		byValue := func(a, b item) int { return cmp.Compare(a.value, b.value) }
+
+		items, q := drawSortQ(t, rapid.Custom(func(t *rapid.T) item {
+			return item{
+				value: rapid.IntRange(0, 3).Draw(t, "value"),
+				pos:   rapid.Int().Draw(t, "pos"),
+			}
+		}))
+		q.SortStableFunc(byValue)
+
+		slices.SortStableFunc(items, byValue)
+		assert.Equal(t, items, q.Snapshot(make([]item, 0)))
+	}))
+
+	t.Run("BinarySearch", rapid.MakeCheck(func(t *rapid.T) {
+		items, q := drawSortQ(t, rapid.IntRange(-10, 10))
+		ring.Sort(q)
+		slices.Sort(items)
+
+		target := rapid.IntRange(-11, 11).Draw(t, "target")
+		wantIdx, wantOK := slices.BinarySearch(items, target)
+		gotIdx, gotOK := ring.BinarySearch(q, target)
+		assert.Equal(t, wantIdx, gotIdx, "index")
+		assert.Equal(t, wantOK, gotOK, "found")
+	}))
+}
+
+// drawSortQ draws a list of items
+// and returns it along with a queue holding the same items.
+// The contents of the queue may wrap around the end of its buffer.
+func drawSortQ[T any](t *rapid.T, gen *rapid.Generator[T]) ([]T, *ring.Q[T]) {
+	items := rapid.SliceOf(gen).Draw(t, "items")
+	offset := rapid.IntRange(0, len(items)).Draw(t, "offset")
+
+	q := ring.NewQ[T](len(items))
+	for i := 0; i < offset; i++ {
+		var zero T
+		q.Push(zero)
+		q.Pop()
+	}
+	q.PushSlice(items...)
+	return items, q
+}
+
+func TestBinarySearchFunc(t *testing.T) {
+	t.Parallel()
+
+	type user struct {
+		name string
+		age  int
+	}
+
+	var q ring.Q[user]
+	q.PushSlice(user{"a", 20}, user{"b", 30}, user{"c", 40})
+
+	byAge := func(u user, age int) int { return cmp.Compare(u.age, age) }
+
+	idx, ok := ring.BinarySearchFunc(&q, 30, byAge)
+	assert.True(t, ok, "found")
+	assert.Equal(t, 1, idx, "index")
+
+	idx, ok = ring.BinarySearchFunc(&q, 35, byAge)
+	assert.False(t, ok, "found")
+	assert.Equal(t, 2, idx, "index")
+}
+
+func TestMuQ_SortFunc(t *testing.T) {
+	t.Parallel()
+
+	var q ring.MuQ[int]
+	q.PushSlice(3, 1, 2)
+	q.SortFunc(cmp.Compare[int])
+	assert.Equal(t, []int{1, 2, 3}, q.Snapshot(nil))
+
+	q.SortStableFunc(func(a, b int) int { return cmp.Compare(b, a) })
+	assert.Equal(t, []int{3, 2, 1}, q.Snapshot(nil))
+}
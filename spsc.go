@@ -0,0 +1,160 @@
+package ring
+
+import (
+	"fmt"
+	"math/bits"
+	"sync/atomic"
+)
+
+// _cacheLineSize is the assumed size of a CPU cache line.
+// Fields written by different goroutines are kept this far apart
+// so that they don't share a cache line (false sharing).
+//
+// This is correct for amd64 and most arm64 processors.
+// On processors with larger cache lines,
+// the padding is less effective but still correct.
+const _cacheLineSize = 64
+
+// SPSC is a fixed-capacity FIFO queue backed by a ring buffer
+// for use by exactly one producer goroutine and one consumer goroutine.
+// It does not take locks: the producer and consumer coordinate
+// through atomic counters.
+//
+// Only one goroutine at a time may call TryPush (the producer),
+// and only one goroutine at a time may call TryPop (the consumer).
+// They may be the same goroutine.
+// Len, Cap, and Empty may be called from any goroutine.
+// To hand off the producer or consumer role to another goroutine,
+// synchronize the two goroutines, e.g. with a channel or a mutex.
+//
+// For any other use, use [MuQ] instead.
+// SPSC is most useful for pipeline stages connected one-to-one,
+// where MuQ's lock dominates the cost of pushing and popping.
+//
+// SPSC never grows: TryPush fails when the queue is full.
+type SPSC[T any] struct {
+	_ [_cacheLineSize]byte
+
+	// Consumer-owned.
+	// head is the number of items popped so far.
+	// tailCache is the consumer's last view of tail;
+	// it's refreshed only when the queue appears empty.
+	head      atomic.Uint64
+	tailCache uint64
+	_         [_cacheLineSize - 16]byte
+
+	// Producer-owned.
+	// tail is the number of items pushed so far.
+	// headCache is the producer's last view of head;
+	// it's refreshed only when the queue appears full.
+	tail      atomic.Uint64
+	headCache uint64
+	_         [_cacheLineSize - 16]byte
+
+	// Read-only after construction.
+	buff []T
+	mask uint64 // len(buff) - 1
+}
+
+// NewSPSC returns a new single-producer, single-consumer queue
+// that holds at least capacity items.
+// The capacity is rounded up to the next power of two.
+// It panics if capacity is not positive.
+//
+// The memory for the queue is allocated upfront.
+// The queue never grows or shrinks.
+func NewSPSC[T any](capacity int) *SPSC[T] {
+	if capacity <= 0 {
+		panic(fmt.Sprintf("invalid capacity: %d", capacity))
+	}
+
+	size := 1 << bits.Len(uint(capacity-1))
+	return &SPSC[T]{
+		buff: make([]T, size),
+		mask: uint64(size - 1),
+	}
+}
+
+// Cap returns the maximum number of items the queue can hold.
+// This is a power of two.
+//
+// This is an O(1) operation and does not allocate.
+func (s *SPSC[T]) Cap() int {
+	return len(s.buff)
+}
+
+// Len returns the number of items in the queue.
+//
+// If the producer or consumer are running concurrently,
+// the result may be stale by the time it's returned.
+//
+// This is an O(1) operation and does not allocate.
+func (s *SPSC[T]) Len() int {
+	// Load head first: tail only increases,
+	// so it's never behind the head we loaded.
+	// Between the two loads, the consumer may pop items
+	// and the producer may replace them,
+	// so the difference may exceed the capacity.
+	head := s.head.Load()
+	return int(min(s.tail.Load()-head, uint64(len(s.buff))))
+}
+
+// Empty returns true if the queue is empty.
+//
+// If the producer or consumer are running concurrently,
+// the result may be stale by the time it's returned.
+//
+// This is an O(1) operation and does not allocate.
+func (s *SPSC[T]) Empty() bool {
+	return s.Len() == 0
+}
+
+// TryPush adds x to the back of the queue.
+// It returns false if the queue is full.
+// Otherwise, it returns true.
+//
+// Only the producer goroutine may call TryPush.
+//
+// This is an O(1) operation and does not allocate.
+func (s *SPSC[T]) TryPush(x T) bool {
+	tail := s.tail.Load()
+	if tail-s.headCache == uint64(len(s.buff)) {
+		// Appears full.
+		// Check if the consumer has made room since we last looked.
+		s.headCache = s.head.Load()
+		if tail-s.headCache == uint64(len(s.buff)) {
+			return false
+		}
+	}
+
+	s.buff[tail&s.mask] = x
+	s.tail.Store(tail + 1) // publish the item to the consumer
+	return true
+}
+
+// TryPop removes and returns the item at the front of the queue.
+// It returns false if the queue is empty.
+// Otherwise, it returns true and the item.
+//
+// Only the consumer goroutine may call TryPop.
+//
+// This is an O(1) operation and does not allocate.
+// The removed slot is zeroed out so that the item may be garbage collected.
+func (s *SPSC[T]) TryPop() (x T, ok bool) {
+	head := s.head.Load()
+	if head == s.tailCache {
+		// Appears empty.
+		// Check if the producer has pushed since we last looked.
+		s.tailCache = s.tail.Load()
+		if head == s.tailCache {
+			return x, false
+		}
+	}
+
+	i := head & s.mask
+	x = s.buff[i]
+	var zero T
+	s.buff[i] = zero
+	s.head.Store(head + 1) // release the slot to the producer
+	return x, true
+}
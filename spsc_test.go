@@ -0,0 +1,173 @@
+package ring_test
+
+import (
+	"container/list"
+	"runtime"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.abhg.dev/container/ring"
+	"pgregory.net/rapid"
+)
+
+func TestNewSPSC(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		give int
+		want int
+	}{
+		{1, 1},
+		{2, 2},
+		{3, 4},
+		{4, 4},
+		{5, 8},
+		{1000, 1024},
+		{1024, 1024},
+	}
+
+	for _, tt := range tests {
+		assert.Equal(t, tt.want, ring.NewSPSC[int](tt.give).Cap(), "capacity %d", tt.give)
+	}
+
+	assert.PanicsWithValue(t, "invalid capacity: 0", func() {
+		ring.NewSPSC[int](0)
+	})
+	assert.PanicsWithValue(t, "invalid capacity: -1", func() {
+		ring.NewSPSC[int](-1)
+	})
+}
+
+func TestSPSC(t *testing.T) {
+	t.Parallel()
+
+	q := ring.NewSPSC[int](4)
+	assert.True(t, q.Empty())
+
+	_, ok := q.TryPop()
+	assert.False(t, ok)
+
+	for i := range 4 {
+		require.True(t, q.TryPush(i))
+	}
+	assert.False(t, q.TryPush(4), "full")
+	assert.Equal(t, 4, q.Len())
+
+	// Wrap around the end of the buffer a few times.
+	for i := range 10 {
+		x, ok := q.TryPop()
+		require.True(t, ok)
+		assert.Equal(t, i, x)
+		require.True(t, q.TryPush(i+4))
+	}
+
+	for i := 10; i < 14; i++ {
+		x, ok := q.TryPop()
+		require.True(t, ok)
+		assert.Equal(t, i, x)
+	}
+	assert.True(t, q.Empty())
+}
+
+func TestSPSC_rapid(t *testing.T) {
+	t.Parallel()
+
+	rapid.Check(t, func(t *rapid.T) {
+		capacity := rapid.IntRange(1, 64).Draw(t, "capacity")
+		q := ring.NewSPSC[int](capacity)
+		golden := list.New()
+
+		t.Repeat(map[string]func(*rapid.T){
+			"TryPush": func(t *rapid.T) {
+				x := rapid.Int().Draw(t, "x")
+				ok := q.TryPush(x)
+				assert.Equal(t, golden.Len() < q.Cap(), ok)
+				if ok {
+					golden.PushBack(x)
+				}
+			},
+			"TryPop": func(t *rapid.T) {
+				x, ok := q.TryPop()
+				if golden.Len() == 0 {
+					assert.False(t, ok)
+					return
+				}
+				assert.True(t, ok)
+				assert.Equal(t, golden.Remove(golden.Front()), x)
+			},
+			"": func(t *rapid.T) {
+				assert.Equal(t, golden.Len(), q.Len())
+				assert.Equal(t, golden.Len() == 0, q.Empty())
+			},
+		})
+	})
+}
+
+// Runs a producer and a consumer concurrently
+// and verifies that every item arrives in order.
+// Run with -race to verify that the atomics
+// order accesses to the buffer correctly.
+func TestSPSC_concurrent(t *testing.T) {
+	t.Parallel()
+
+	const Items = 100_000
+
+	type item struct {
+		n int
+		s *int // pointer to catch torn or stale reads
+	}
+
+	q := ring.NewSPSC[item](64)
+
+	var wg sync.WaitGroup
+	wg.Add(2)
+	go func() {
+		defer wg.Done()
+		for i := 0; i < Items; {
+			n := i
+			if q.TryPush(item{n: i, s: &n}) {
+				i++
+			} else {
+				runtime.Gosched()
+			}
+		}
+	}()
+
+	go func() {
+		defer wg.Done()
+		for i := 0; i < Items; {
+			x, ok := q.TryPop()
+			if !ok {
+				runtime.Gosched()
+				continue
+			}
+			if !assert.Equal(t, i, x.n) || !assert.Equal(t, i, *x.s) {
+				return
+			}
+			i++
+		}
+	}()
+
+	// Observers may call Len from any goroutine.
+	done := make(chan struct{})
+	go func() {
+		for {
+			select {
+			case <-done:
+				return
+			default:
+				if n := q.Len(); n < 0 || n > q.Cap() {
+					t.Errorf("len out of range: %d", n)
+					return
+				}
+				runtime.Gosched()
+			}
+		}
+	}()
+
+	wg.Wait()
+	close(done)
+	assert.True(t, q.Empty())
+}
@@ -0,0 +1,323 @@
+package ring
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+)
+
+// Codec encodes and decodes individual items of a queue
+// for use with [Encoder] and [Decoder].
+//
+// The Encoder takes care of delimiting items in the stream,
+// so a Codec does not need to record the length of an item.
+type Codec[T any] interface {
+	// AppendEncode appends the encoded form of x to dst
+	// and returns the result.
+	AppendEncode(dst []byte, x T) ([]byte, error)
+
+	// Decode decodes an item from data,
+	// which holds exactly the bytes produced by AppendEncode.
+	//
+	// data is only valid until Decode returns:
+	// Decode must copy any portion of it that it retains.
+	Decode(data []byte) (T, error)
+}
+
+// Streams written by Encoder have the following format:
+//
+//	stream = magic version queue*
+//	magic  = "RNGQ"
+//	queue  = count item*  // count items
+//	item   = length bytes // length bytes
+//
+// version, count, and length are unsigned varints.
+//
+// The header (magic and version) is written once per stream,
+// so a stream may hold any number of queues.
+const (
+	_streamMagic   = "RNGQ"
+	_streamVersion = 1
+
+	// _maxDecodeReserve limits how much room the Decoder makes upfront
+	// based on the item count in the stream,
+	// so that a corrupt count can't trigger a huge allocation.
+	_maxDecodeReserve = 1 << 16
+)
+
+// Encoder writes queues to an [io.Writer] one item at a time,
+// encoding each item with a [Codec].
+// Unlike [Q.MarshalBinary], it never holds the encoded form
+// of the whole queue in memory.
+//
+// Queues written by an Encoder may be read back with a [Decoder]
+// that uses a compatible Codec.
+type Encoder[T any] struct {
+	w     *bufio.Writer
+	codec Codec[T]
+	buf   []byte // scratch space for encoding items
+
+	wroteHeader bool
+
+	// err is the first error encountered.
+	// Once set, the Encoder is unusable.
+	err error
+}
+
+// NewEncoder returns an Encoder that writes to w,
+// encoding items with codec.
+//
+// The Encoder buffers its writes.
+// Each call to Encode flushes the buffer before returning.
+func NewEncoder[T any](w io.Writer, codec Codec[T]) *Encoder[T] {
+	return &Encoder[T]{
+		w:     bufio.NewWriter(w),
+		codec: codec,
+	}
+}
+
+// Encode writes the items in q to the stream, front to back.
+// The queue is not modified.
+//
+// The first call to Encode also writes the stream header.
+// Encode may be called multiple times to write multiple queues.
+//
+// As with [Q.All], the queue must not be modified while it's being encoded.
+// This includes from the Codec.
+//
+// If Encode fails, either because the Codec failed or because
+// writing to the underlying [io.Writer] failed,
+// the Encoder is left permanently failed:
+// this and all later calls to Encode return the same error,
+// and nothing more is written.
+// Queues written by earlier successful calls remain readable,
+// but the stream may end with part of the queue that failed.
+func (e *Encoder[T]) Encode(q *Q[T]) error {
+	if e.err != nil {
+		return e.err
+	}
+	if err := e.encode(q); err != nil {
+		e.err = err
+		return err
+	}
+	return nil
+}
+
+func (e *Encoder[T]) encode(q *Q[T]) error {
+	if err := e.writeHeader(); err != nil {
+		return err
+	}
+
+	n := q.Len()
+	if err := e.writeUvarint(uint64(n)); err != nil {
+		return err
+	}
+
+	i := 0
+	a, b := q.span(0, n)
+	for _, seg := range [][]T{a, b} {
+		for _, x := range seg {
+			if err := e.writeItem(i, x); err != nil {
+				return err
+			}
+			i++
+		}
+	}
+	return e.w.Flush()
+}
+
+// EncodeMuQ writes the items in q to the stream, front to back.
+// The queue is not modified.
+//
+// The queue is encoded under its read lock,
+// so goroutines that modify the queue are blocked until EncodeMuQ returns.
+// This includes the time spent writing to the underlying [io.Writer].
+//
+// See [Encoder.Encode] for details.
+func (e *Encoder[T]) EncodeMuQ(q *MuQ[T]) error {
+	q.mu.RLock()
+	defer q.mu.RUnlock()
+	return e.Encode(&q.q)
+}
+
+func (e *Encoder[T]) writeHeader() error {
+	if e.wroteHeader {
+		return nil
+	}
+	if _, err := e.w.WriteString(_streamMagic); err != nil {
+		return err
+	}
+	if err := e.writeUvarint(_streamVersion); err != nil {
+		return err
+	}
+	e.wroteHeader = true
+	return nil
+}
+
+func (e *Encoder[T]) writeItem(i int, x T) error {
+	var err error
+	e.buf, err = e.codec.AppendEncode(e.buf[:0], x)
+	if err != nil {
+		return fmt.Errorf("encode item %d: %w", i, err)
+	}
+
+	if err := e.writeUvarint(uint64(len(e.buf))); err != nil {
+		return err
+	}
+	_, err = e.w.Write(e.buf)
+	return err
+}
+
+func (e *Encoder[T]) writeUvarint(v uint64) error {
+	var buf [binary.MaxVarintLen64]byte
+	n := binary.PutUvarint(buf[:], v)
+	_, err := e.w.Write(buf[:n])
+	return err
+}
+
+// Decoder reads queues written by an [Encoder] from an [io.Reader],
+// decoding each item with a [Codec].
+// Items are pushed into the destination queue as they are decoded,
+// so the encoded form of the queue is never held in memory in full.
+type Decoder[T any] struct {
+	r     *bufio.Reader
+	codec Codec[T]
+	buf   bytes.Buffer // scratch space for reading items
+
+	readHeader bool
+}
+
+// NewDecoder returns a Decoder that reads from r,
+// decoding items with codec.
+//
+// The Decoder buffers its reads,
+// so it may read data from r beyond the queues it decodes.
+func NewDecoder[T any](r io.Reader, codec Codec[T]) *Decoder[T] {
+	return &Decoder[T]{
+		r:     bufio.NewReader(r),
+		codec: codec,
+	}
+}
+
+// Decode reads the next queue from the stream
+// and pushes its items to the back of q, front to back.
+// To restore a queue, pass an empty queue.
+//
+// The first call to Decode also reads and verifies the stream header.
+// Decode returns [io.EOF] if the stream has no more queues.
+// If the stream ends partway through a queue,
+// it returns [io.ErrUnexpectedEOF].
+//
+// If Decode fails partway through a queue,
+// the items decoded so far remain in q.
+// If q was created with [WithMaxCapacity]
+// and it runs out of room, Decode returns an error wrapping [ErrFull].
+func (d *Decoder[T]) Decode(q *Q[T]) error {
+	return d.decode(q.Grow, func(x T) error {
+		if !q.TryPush(x) {
+			return ErrFull
+		}
+		return nil
+	})
+}
+
+// DecodeMuQ reads the next queue from the stream
+// and pushes its items to the back of q, front to back.
+//
+// The queue is locked separately for each item pushed into it,
+// so other goroutines may observe it partially decoded
+// and may interleave their own operations with the decoded items.
+// If q is closed, DecodeMuQ returns an error wrapping [ErrClosed],
+// and if it's full, an error wrapping [ErrFull].
+//
+// See [Decoder.Decode] for details.
+func (d *Decoder[T]) DecodeMuQ(q *MuQ[T]) error {
+	return d.decode(q.Grow, func(x T) error {
+		q.mu.Lock()
+		defer q.mu.Unlock()
+		return q.tryPush(x)
+	})
+}
+
+// decode reads the next queue from the stream,
+// adding its items with push.
+// grow is called with the expected number of items before they're added.
+func (d *Decoder[T]) decode(grow func(int), push func(T) error) error {
+	if err := d.readStreamHeader(); err != nil {
+		return err
+	}
+
+	count, err := binary.ReadUvarint(d.r)
+	if err != nil {
+		// A clean end of stream between queues is reported as io.EOF.
+		return err
+	}
+	grow(int(min(count, _maxDecodeReserve)))
+
+	for i := uint64(0); i < count; i++ {
+		x, err := d.readItem()
+		if err != nil {
+			return fmt.Errorf("decode item %d: %w", i, noEOF(err))
+		}
+		if err := push(x); err != nil {
+			return fmt.Errorf("decode item %d: %w", i, err)
+		}
+	}
+	return nil
+}
+
+func (d *Decoder[T]) readStreamHeader() error {
+	if d.readHeader {
+		return nil
+	}
+
+	var magic [len(_streamMagic)]byte
+	if _, err := io.ReadFull(d.r, magic[:]); err != nil {
+		return err
+	}
+	if string(magic[:]) != _streamMagic {
+		return errors.New("not a queue stream: bad header")
+	}
+
+	version, err := binary.ReadUvarint(d.r)
+	if err != nil {
+		return noEOF(err)
+	}
+	if version != _streamVersion {
+		return fmt.Errorf("unsupported queue stream version: %d", version)
+	}
+
+	d.readHeader = true
+	return nil
+}
+
+func (d *Decoder[T]) readItem() (x T, err error) {
+	n, err := binary.ReadUvarint(d.r)
+	if err != nil {
+		return x, err
+	}
+
+	// Read through a bytes.Buffer instead of allocating n bytes upfront
+	// so that memory use is bounded by the data actually present.
+	d.buf.Reset()
+	if _, err := d.buf.ReadFrom(io.LimitReader(d.r, int64(n))); err != nil {
+		return x, err
+	}
+	if uint64(d.buf.Len()) < n {
+		return x, io.ErrUnexpectedEOF
+	}
+
+	return d.codec.Decode(d.buf.Bytes())
+}
+
+// noEOF converts io.EOF to io.ErrUnexpectedEOF.
+// Use it where the stream must not end.
+func noEOF(err error) error {
+	if err == io.EOF {
+		return io.ErrUnexpectedEOF
+	}
+	return err
+}
@@ -0,0 +1,278 @@
+package ring_test
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"io"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.abhg.dev/container/ring"
+	"pgregory.net/rapid"
+)
+
+// intCodec encodes ints as signed varints.
+type intCodec struct{}
+
+var _ ring.Codec[int] = intCodec{}
+
+func (intCodec) AppendEncode(dst []byte, x int) ([]byte, error) {
+	return binary.AppendVarint(dst, int64(x)), nil
+}
+
+func (intCodec) Decode(data []byte) (int, error) {
+	x, n := binary.Varint(data)
+	if n <= 0 || n != len(data) {
+		return 0, errors.New("bad varint")
+	}
+	return int(x), nil
+}
+
+// failCodec fails to encode and decode items equal to bad.
+type failCodec struct {
+	intCodec
+
+	bad int
+}
+
+func (c failCodec) AppendEncode(dst []byte, x int) ([]byte, error) {
+	if x == c.bad {
+		return nil, errors.New("great sadness")
+	}
+	return c.intCodec.AppendEncode(dst, x)
+}
+
+func (c failCodec) Decode(data []byte) (int, error) {
+	x, err := c.intCodec.Decode(data)
+	if err == nil && x == c.bad {
+		return 0, errors.New("great sadness")
+	}
+	return x, err
+}
+
+func encodeQueues(t testing.TB, qs ...*ring.Q[int]) []byte {
+	var buf bytes.Buffer
+	enc := ring.NewEncoder[int](&buf, intCodec{})
+	for _, q := range qs {
+		require.NoError(t, enc.Encode(q))
+	}
+	return buf.Bytes()
+}
+
+func TestEncoder_roundTrip(t *testing.T) {
+	t.Parallel()
+
+	var empty ring.Q[int]
+	data := encodeQueues(t, newWrappedQ(), &empty, ring.FromSlice([]int{-1, 1 << 40}))
+
+	dec := ring.NewDecoder[int](bytes.NewReader(data), intCodec{})
+
+	var q1, q2, q3 ring.Q[int]
+	require.NoError(t, dec.Decode(&q1))
+	require.NoError(t, dec.Decode(&q2))
+	require.NoError(t, dec.Decode(&q3))
+	assert.Equal(t, []int{1, 2, 3, 4, 5}, q1.Snapshot(nil))
+	assert.True(t, q2.Empty())
+	assert.Equal(t, []int{-1, 1 << 40}, q3.Snapshot(nil))
+
+	var q4 ring.Q[int]
+	assert.ErrorIs(t, dec.Decode(&q4), io.EOF)
+}
+
+func TestDecoder_appends(t *testing.T) {
+	t.Parallel()
+
+	data := encodeQueues(t, ring.FromSlice([]int{3, 4}))
+
+	q := ring.FromSlice([]int{1, 2})
+	require.NoError(t, ring.NewDecoder[int](bytes.NewReader(data), intCodec{}).Decode(q))
+	assert.Equal(t, []int{1, 2, 3, 4}, q.Snapshot(nil))
+}
+
+func TestDecoder_errors(t *testing.T) {
+	t.Parallel()
+
+	valid := encodeQueues(t, newWrappedQ())
+
+	tests := []struct {
+		name    string
+		give    []byte
+		codec   ring.Codec[int]
+		wantErr error  // if set, checked with errors.Is
+		wantMsg string // if set, checked with ErrorContains
+		want    []int  // items decoded before the error
+	}{
+		{
+			name:    "Empty",
+			give:    nil,
+			wantErr: io.EOF,
+		},
+		{
+			name:    "BadMagic",
+			give:    []byte("NOPE\x01\x00"),
+			wantMsg: "bad header",
+		},
+		{
+			name:    "ShortMagic",
+			give:    []byte("RN"),
+			wantErr: io.ErrUnexpectedEOF,
+		},
+		{
+			name:    "MissingVersion",
+			give:    []byte("RNGQ"),
+			wantErr: io.ErrUnexpectedEOF,
+		},
+		{
+			name:    "BadVersion",
+			give:    []byte("RNGQ\x02\x00"),
+			wantMsg: "unsupported queue stream version: 2",
+		},
+		{
+			name:    "NoQueues",
+			give:    []byte("RNGQ\x01"),
+			wantErr: io.EOF,
+		},
+		{
+			name:    "TruncatedItem",
+			give:    valid[:len(valid)-1],
+			wantErr: io.ErrUnexpectedEOF,
+			want:    []int{1, 2, 3, 4},
+		},
+		{
+			name:    "MissingItems",
+			give:    valid[:len(valid)-4],
+			wantErr: io.ErrUnexpectedEOF,
+			want:    []int{1, 2, 3},
+		},
+		{
+			name: "HugeCount",
+			// Claims 2^62 items but holds none.
+			give:    []byte("RNGQ\x01\x80\x80\x80\x80\x80\x80\x80\x80\x40"),
+			wantErr: io.ErrUnexpectedEOF,
+		},
+		{
+			name: "HugeItem",
+			// Claims a 2^62 byte item but holds one byte.
+			give:    []byte("RNGQ\x01\x01\x80\x80\x80\x80\x80\x80\x80\x80\x40\x00"),
+			wantErr: io.ErrUnexpectedEOF,
+		},
+		{
+			name:    "Codec",
+			give:    valid,
+			codec:   failCodec{bad: 3},
+			wantMsg: "decode item 2: great sadness",
+			want:    []int{1, 2},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			codec := tt.codec
+			if codec == nil {
+				codec = intCodec{}
+			}
+
+			var q ring.Q[int]
+			err := ring.NewDecoder(bytes.NewReader(tt.give), codec).Decode(&q)
+			require.Error(t, err)
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+			}
+			if tt.wantMsg != "" {
+				assert.ErrorContains(t, err, tt.wantMsg)
+			}
+			assert.Equal(t, tt.want, q.Snapshot(nil))
+		})
+	}
+}
+
+func TestDecoder_maxCapacity(t *testing.T) {
+	t.Parallel()
+
+	data := encodeQueues(t, newWrappedQ())
+
+	q := ring.NewQWithOptions[int](3, ring.WithMaxCapacity(3))
+	err := ring.NewDecoder[int](bytes.NewReader(data), intCodec{}).Decode(q)
+	assert.ErrorIs(t, err, ring.ErrFull)
+	assert.Equal(t, []int{1, 2, 3}, q.Snapshot(nil))
+}
+
+func TestEncoder_codecError(t *testing.T) {
+	t.Parallel()
+
+	var buf bytes.Buffer
+	enc := ring.NewEncoder[int](&buf, failCodec{bad: 4})
+	require.NoError(t, enc.Encode(ring.FromSlice([]int{1, 2})))
+
+	err := enc.Encode(newWrappedQ())
+	assert.ErrorContains(t, err, "encode item 3: great sadness")
+
+	// The Encoder stays failed and doesn't write anything more.
+	size := buf.Len()
+	assert.Equal(t, err, enc.Encode(ring.FromSlice([]int{5, 6})))
+	assert.Equal(t, size, buf.Len())
+
+	// The queue written before the failure is intact,
+	// and nothing from the failed queue made it to the stream.
+	dec := ring.NewDecoder[int](&buf, intCodec{})
+	var q ring.Q[int]
+	require.NoError(t, dec.Decode(&q))
+	assert.Equal(t, []int{1, 2}, q.Snapshot(nil))
+	assert.ErrorIs(t, dec.Decode(&q), io.EOF)
+}
+
+// errWriter fails all writes.
+type errWriter struct{ err error }
+
+func (w errWriter) Write([]byte) (int, error) { return 0, w.err }
+
+func TestEncoder_writeError(t *testing.T) {
+	t.Parallel()
+
+	giveErr := errors.New("disk full")
+	enc := ring.NewEncoder[int](errWriter{giveErr}, intCodec{})
+	assert.ErrorIs(t, enc.Encode(newWrappedQ()), giveErr)
+	assert.ErrorIs(t, enc.Encode(newWrappedQ()), giveErr)
+}
+
+func TestEncoder_MuQ(t *testing.T) {
+	t.Parallel()
+
+	give := ring.MuQFromSlice([]int{1, 2, 3})
+
+	var buf bytes.Buffer
+	require.NoError(t, ring.NewEncoder[int](&buf, intCodec{}).EncodeMuQ(give))
+
+	var got ring.MuQ[int]
+	require.NoError(t, ring.NewDecoder[int](&buf, intCodec{}).DecodeMuQ(&got))
+	assert.Equal(t, []int{1, 2, 3}, got.Snapshot(nil))
+}
+
+func TestEncoder_rapid(t *testing.T) {
+	t.Parallel()
+
+	rapid.Check(t, func(t *rapid.T) {
+		items := rapid.SliceOfN(rapid.SliceOf(rapid.Int()), 1, 5).Draw(t, "items")
+
+		var buf bytes.Buffer
+		enc := ring.NewEncoder[int](&buf, intCodec{})
+		for _, xs := range items {
+			require.NoError(t, enc.Encode(ring.FromSlice(xs)))
+		}
+
+		dec := ring.NewDecoder[int](&buf, intCodec{})
+		for _, xs := range items {
+			var q ring.Q[int]
+			require.NoError(t, dec.Decode(&q))
+			require.Equal(t, len(xs), q.Len())
+			require.True(t, ring.Equal(ring.FromSlice(xs), &q))
+		}
+
+		var q ring.Q[int]
+		require.ErrorIs(t, dec.Decode(&q), io.EOF)
+	})
+}
@@ -0,0 +1,103 @@
+package ring
+
+import "sync/atomic"
+
+// Unbounded is a channel with an unbounded buffer.
+// Sends to its input channel never block for long:
+// items are buffered in a [Q] until they're received
+// from its output channel.
+//
+// Items are received from Out in the order they were sent to In.
+// After In is closed, Out is closed once all buffered items
+// have been received.
+//
+// An internal goroutine moves items from In to the buffer
+// and from the buffer to Out.
+// It exits when In is closed and the buffer has been drained,
+// so always close In when you're done sending,
+// and receive from Out until it's closed.
+//
+// The buffer follows the same growth strategy as [Q]:
+// it grows to fit the largest backlog seen so far, and does not shrink.
+// This suits producers that send in bursts or at a steady rate
+// relative to the consumer.
+// See package documentation for details.
+//
+// Use [NewUnbounded] to create an Unbounded.
+// The zero value is not usable.
+type Unbounded[T any] struct {
+	in  chan T
+	out chan T
+
+	// len is the number of items in the buffer.
+	// The buffer is owned by the internal goroutine,
+	// so this is tracked separately for Len.
+	len atomic.Int64
+}
+
+// NewUnbounded returns a new Unbounded channel
+// whose buffer starts with the given capacity.
+// If capacity is zero, the buffer is initialized with a default capacity.
+//
+// It starts the internal goroutine that services the channel.
+func NewUnbounded[T any](capacity int) *Unbounded[T] {
+	u := Unbounded[T]{
+		in:  make(chan T),
+		out: make(chan T),
+	}
+	go u.run(NewQ[T](capacity))
+	return &u
+}
+
+// In returns the channel to send items to.
+// Close it when you're done sending.
+func (u *Unbounded[T]) In() chan<- T {
+	return u.in
+}
+
+// Out returns the channel to receive items from.
+// It's closed after In is closed and all buffered items have been received.
+func (u *Unbounded[T]) Out() <-chan T {
+	return u.out
+}
+
+// Len returns the number of items sent to In
+// that have not yet been received from Out.
+//
+// The value may be stale by the time it's returned
+// if other goroutines are sending or receiving concurrently.
+func (u *Unbounded[T]) Len() int {
+	return int(u.len.Load())
+}
+
+func (u *Unbounded[T]) run(buf *Q[T]) {
+	defer close(u.out)
+
+	in := u.in
+	for in != nil || !buf.Empty() {
+		// Sending on a nil channel blocks forever,
+		// so the send case is disabled while the buffer is empty.
+		var (
+			out  chan T
+			next T
+		)
+		if x, ok := buf.TryPeek(); ok {
+			out, next = u.out, x
+		}
+
+		select {
+		case x, ok := <-in:
+			if !ok {
+				// Stop receiving, but keep draining the buffer.
+				in = nil
+				continue
+			}
+			buf.Push(x)
+			u.len.Add(1)
+
+		case out <- next:
+			buf.Pop()
+			u.len.Add(-1)
+		}
+	}
+}
@@ -0,0 +1,123 @@
+package ring_test
+
+import (
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.abhg.dev/container/ring"
+)
+
+func TestUnbounded(t *testing.T) {
+	t.Parallel()
+
+	t.Run("SendsDoNotBlock", func(t *testing.T) {
+		t.Parallel()
+
+		const N = 10_000
+
+		u := ring.NewUnbounded[int](0)
+
+		// Nothing is receiving while these are sent.
+		for i := range N {
+			u.In() <- i
+		}
+		require.Eventually(t, func() bool { return u.Len() == N },
+			time.Second, time.Millisecond)
+		close(u.In())
+
+		want := 0
+		for x := range u.Out() {
+			assert.Equal(t, want, x)
+			want++
+		}
+		assert.Equal(t, N, want)
+		assert.Zero(t, u.Len())
+	})
+
+	t.Run("Interleaved", func(t *testing.T) {
+		t.Parallel()
+
+		u := ring.NewUnbounded[string](1)
+		u.In() <- "a"
+		assert.Equal(t, "a", <-u.Out())
+
+		u.In() <- "b"
+		u.In() <- "c"
+		assert.Equal(t, "b", <-u.Out())
+		u.In() <- "d"
+		close(u.In())
+
+		var got []string
+		for x := range u.Out() {
+			got = append(got, x)
+		}
+		assert.Equal(t, []string{"c", "d"}, got)
+	})
+
+	t.Run("CloseEmpty", func(t *testing.T) {
+		t.Parallel()
+
+		u := ring.NewUnbounded[int](0)
+		close(u.In())
+
+		_, ok := <-u.Out()
+		assert.False(t, ok)
+	})
+}
+
+// Runs multiple senders and receivers through an Unbounded
+// and verifies that every item is received.
+func TestUnbounded_concurrent(t *testing.T) {
+	t.Parallel()
+
+	const (
+		Senders   = 4
+		Receivers = 4
+		Items     = 1000 // per sender
+	)
+
+	type item struct{ sender, seq int }
+
+	u := ring.NewUnbounded[item](0)
+
+	var senders sync.WaitGroup
+	for s := range Senders {
+		senders.Add(1)
+		go func() {
+			defer senders.Done()
+			for i := range Items {
+				u.In() <- item{s, i}
+			}
+		}()
+	}
+	go func() {
+		senders.Wait()
+		close(u.In())
+	}()
+
+	var (
+		mu   sync.Mutex
+		got  [Senders][]int
+		recv sync.WaitGroup
+	)
+	for range Receivers {
+		recv.Add(1)
+		go func() {
+			defer recv.Done()
+			for x := range u.Out() {
+				mu.Lock()
+				got[x.sender] = append(got[x.sender], x.seq)
+				mu.Unlock()
+			}
+		}()
+	}
+	recv.Wait()
+
+	for s, seqs := range got {
+		assert.Len(t, seqs, Items, "sender %d", s)
+	}
+	assert.Zero(t, u.Len())
+}
@@ -0,0 +1,96 @@
+package ring
+
+import "slices"
+
+// Segments returns the contents of the queue as two slices
+// that alias the queue's internal buffer.
+// The contents of the queue are a followed by b.
+// b is empty unless the contents wrap around the end of the buffer.
+//
+//	a, b := q.Segments()
+//	bufs := net.Buffers{a, b}
+//
+// This provides read access to the contents of the queue
+// without copying or allocating.
+//
+// The returned slices are valid only until the next operation
+// that adds or removes items or changes the capacity of the queue
+// (e.g. Push, Pop, Clear, Grow).
+// Writing to them modifies the items in the queue.
+// Appending to them never modifies the queue.
+//
+// In builds with the 'ringdebug' tag,
+// the first such operation after Segments is called
+// moves the queue to a new buffer and zeroes out the old one
+// so that stale slices are easier to detect.
+func (q *Q[T]) Segments() (a, b []T) {
+	a, b = q.span(0, q.Len())
+	q.markViewed()
+	return a[:len(a):len(a)], b[:len(b):len(b)]
+}
+
+// MakeContiguous rearranges the internal buffer of the queue in place
+// so that its contents are in a single slice,
+// and returns that slice.
+// It does not allocate.
+//
+// This is an O(1) operation if the contents are already contiguous.
+// Otherwise, it's an O(n) operation where n is the capacity of the queue.
+//
+// The returned slice aliases the queue's internal buffer,
+// and it's subject to the same rules as the slices returned by Segments.
+// MakeContiguous also invalidates slices
+// previously returned by Segments or MakeContiguous.
+func (q *Q[T]) MakeContiguous() []T {
+	q.mutate()
+
+	if q.head > q.tail {
+		// The contents are buff[head:] + buff[:tail].
+		// Rotate the buffer left by head
+		// to move the first item to index 0.
+		n := q.Len()
+		slices.Reverse(q.buff[:q.head])
+		slices.Reverse(q.buff[q.head:])
+		slices.Reverse(q.buff)
+		q.head = 0
+		q.tail = n
+	}
+
+	q.markViewed()
+	return q.buff[q.head:q.tail:q.tail]
+}
+
+// markViewed records in debug builds
+// that slices aliasing the buffer were handed out.
+func (q *Q[T]) markViewed() {
+	if debug && q.head != q.tail {
+		q.viewed = true
+	}
+}
+
+// mutate must be called before any operation
+// that adds, removes, or reorders items or changes the capacity of the queue.
+// Operations that turn out to be no-ops must not call it.
+// It invalidates iterators returned by All and Backward,
+// and views handed out by Segments and MakeContiguous.
+func (q *Q[T]) mutate() {
+	q.mods++
+	q.invalidateViews()
+}
+
+// invalidateViews is called by mutate.
+//
+// In debug builds, if slices aliasing the buffer were handed out,
+// it moves the contents of the queue to a new buffer
+// and zeroes out the old one
+// so that stale slices don't appear to work.
+func (q *Q[T]) invalidateViews() {
+	if !debug || !q.viewed {
+		return
+	}
+
+	q.viewed = false
+	old := q.buff
+	q.resize(len(old))
+	clear(old)
+}
@@ -0,0 +1,36 @@
+//go:build ringdebug
+
+package ring_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestQ_Segments_staleDebug(t *testing.T) {
+	t.Parallel()
+
+	q := newWrappedQ()
+	a, b := q.Segments()
+	q.Push(6)
+
+	assert.Equal(t, []int{0, 0, 0}, a, "stale view must be zeroed")
+	assert.Equal(t, []int{0, 0}, b, "stale view must be zeroed")
+	assert.Equal(t, []int{1, 2, 3, 4, 5, 6}, q.Snapshot(nil))
+
+	// Writing to a stale view doesn't affect the queue.
+	a[0] = 42
+	assert.Equal(t, 1, q.Peek())
+}
+
+func TestQ_MakeContiguous_staleDebug(t *testing.T) {
+	t.Parallel()
+
+	q := newWrappedQ()
+	items := q.MakeContiguous()
+	q.Pop()
+
+	assert.Equal(t, []int{0, 0, 0, 0, 0}, items, "stale view must be zeroed")
+	assert.Equal(t, []int{2, 3, 4, 5}, q.Snapshot(nil))
+}
@@ -0,0 +1,104 @@
+package ring_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"go.abhg.dev/container/ring"
+)
+
+// newWrappedQ returns a queue holding [1, 2, 3, 4, 5]
+// with contents that wrap around the end of its buffer.
+func newWrappedQ() *ring.Q[int] {
+	q := ring.NewQ[int](6)
+	q.PushSlice(0, 0, 0, 0)
+	q.DiscardN(4)
+	q.PushSlice(1, 2, 3, 4, 5)
+	return q
+}
+
+func TestQ_Segments(t *testing.T) {
+	t.Parallel()
+
+	t.Run("Empty", func(t *testing.T) {
+		t.Parallel()
+
+		var q ring.Q[int]
+		a, b := q.Segments()
+		assert.Empty(t, a)
+		assert.Empty(t, b)
+	})
+
+	t.Run("Contiguous", func(t *testing.T) {
+		t.Parallel()
+
+		q := ring.NewQ[int](6)
+		q.PushSlice(1, 2, 3)
+		a, b := q.Segments()
+		assert.Equal(t, []int{1, 2, 3}, a)
+		assert.Empty(t, b)
+	})
+
+	t.Run("Wrapped", func(t *testing.T) {
+		t.Parallel()
+
+		q := newWrappedQ()
+		a, b := q.Segments()
+		assert.Equal(t, []int{1, 2, 3}, a)
+		assert.Equal(t, []int{4, 5}, b)
+
+		// Appending must not clobber the queue.
+		_ = append(b, 42)
+		assert.Equal(t, []int{1, 2, 3, 4, 5}, q.Snapshot(nil))
+
+		// Writing modifies items in place.
+		a[0] = 10
+		assert.Equal(t, 10, q.Peek())
+	})
+}
+
+func TestQ_Segments_noAlloc(t *testing.T) {
+	// Not parallel: AllocsPerRun doesn't support it.
+
+	q := newWrappedQ()
+	var sum int
+	allocs := testing.AllocsPerRun(10, func() {
+		a, b := q.Segments()
+		for _, x := range a {
+			sum += x
+		}
+		for _, x := range b {
+			sum += x
+		}
+	})
+	assert.Zero(t, allocs, "allocations")
+	assert.NotZero(t, sum)
+}
+
+func TestQ_MakeContiguous(t *testing.T) {
+	t.Parallel()
+
+	t.Run("Empty", func(t *testing.T) {
+		t.Parallel()
+
+		var q ring.Q[int]
+		assert.Empty(t, q.MakeContiguous())
+	})
+
+	t.Run("Wrapped", func(t *testing.T) {
+		t.Parallel()
+
+		q := newWrappedQ()
+		capacity := q.Cap()
+		assert.Equal(t, []int{1, 2, 3, 4, 5}, q.MakeContiguous())
+		assert.Equal(t, capacity, q.Cap(), "capacity must not change")
+
+		a, b := q.Segments()
+		assert.Equal(t, []int{1, 2, 3, 4, 5}, a)
+		assert.Empty(t, b)
+
+		// The queue must still work afterwards.
+		q.PushSlice(6, 7)
+		assert.Equal(t, []int{1, 2, 3, 4, 5, 6, 7}, q.PopN(nil, 10))
+	})
+}